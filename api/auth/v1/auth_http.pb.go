@@ -19,26 +19,90 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationAuthServiceChangeMyPassword = "/auth.v1.AuthService/ChangeMyPassword"
+const OperationAuthServiceConfirmMFAEnrollment = "/auth.v1.AuthService/ConfirmMFAEnrollment"
+const OperationAuthServiceConfirmPasswordReset = "/auth.v1.AuthService/ConfirmPasswordReset"
+const OperationAuthServiceCreateAPIKey = "/auth.v1.AuthService/CreateAPIKey"
+const OperationAuthServiceDisableMFA = "/auth.v1.AuthService/DisableMFA"
+const OperationAuthServiceEnrollMFA = "/auth.v1.AuthService/EnrollMFA"
 const OperationAuthServiceGetCurrentUser = "/auth.v1.AuthService/GetCurrentUser"
+const OperationAuthServiceImpersonate = "/auth.v1.AuthService/Impersonate"
+const OperationAuthServiceIntrospectToken = "/auth.v1.AuthService/IntrospectToken"
+const OperationAuthServiceListAPIKeys = "/auth.v1.AuthService/ListAPIKeys"
+const OperationAuthServiceListMyLoginHistory = "/auth.v1.AuthService/ListMyLoginHistory"
+const OperationAuthServiceListMySessions = "/auth.v1.AuthService/ListMySessions"
+const OperationAuthServiceListUserSessions = "/auth.v1.AuthService/ListUserSessions"
 const OperationAuthServiceLogin = "/auth.v1.AuthService/Login"
 const OperationAuthServiceLogout = "/auth.v1.AuthService/Logout"
+const OperationAuthServiceOIDCCallback = "/auth.v1.AuthService/OIDCCallback"
 const OperationAuthServiceRefreshToken = "/auth.v1.AuthService/RefreshToken"
 const OperationAuthServiceRegister = "/auth.v1.AuthService/Register"
+const OperationAuthServiceRequestPasswordReset = "/auth.v1.AuthService/RequestPasswordReset"
+const OperationAuthServiceResendVerificationEmail = "/auth.v1.AuthService/ResendVerificationEmail"
+const OperationAuthServiceRevokeAPIKey = "/auth.v1.AuthService/RevokeAPIKey"
 const OperationAuthServiceRevokeAllTokens = "/auth.v1.AuthService/RevokeAllTokens"
+const OperationAuthServiceRevokeSession = "/auth.v1.AuthService/RevokeSession"
+const OperationAuthServiceRevokeUserSession = "/auth.v1.AuthService/RevokeUserSession"
+const OperationAuthServiceStartOIDCLogin = "/auth.v1.AuthService/StartOIDCLogin"
+const OperationAuthServiceVerifyEmail = "/auth.v1.AuthService/VerifyEmail"
+const OperationAuthServiceVerifyMFA = "/auth.v1.AuthService/VerifyMFA"
 
 type AuthServiceHTTPServer interface {
+	// ChangeMyPassword Change the password of the current user
+	ChangeMyPassword(context.Context, *ChangeMyPasswordRequest) (*ChangeMyPasswordResponse, error)
+	// ConfirmMFAEnrollment Confirm TOTP enrollment with the first code, returns recovery codes
+	ConfirmMFAEnrollment(context.Context, *ConfirmMFAEnrollmentRequest) (*ConfirmMFAEnrollmentResponse, error)
+	// ConfirmPasswordReset Set a new password with the emailed reset token
+	ConfirmPasswordReset(context.Context, *ConfirmPasswordResetRequest) (*ConfirmPasswordResetResponse, error)
+	// CreateAPIKey Create an API key for the current user (the key is only returned once)
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// DisableMFA Disable TOTP for the current user
+	DisableMFA(context.Context, *DisableMFARequest) (*DisableMFAResponse, error)
+	// EnrollMFA Start TOTP enrollment for the current user
+	EnrollMFA(context.Context, *EnrollMFARequest) (*EnrollMFAResponse, error)
 	// GetCurrentUser Verify token and get current user
 	GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error)
+	// Impersonate Get a short-lived access token acting as another user (user:impersonate, audited)
+	Impersonate(context.Context, *ImpersonateRequest) (*ImpersonateResponse, error)
+	// IntrospectToken RFC 7662 token introspection for trusted clients (HTTP Basic client credentials).
+	// Accepts access tokens, refresh tokens and API keys.
+	IntrospectToken(context.Context, *IntrospectTokenRequest) (*IntrospectTokenResponse, error)
+	// ListAPIKeys List API keys of the current user
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	// ListMyLoginHistory List recent login attempts of the current user, failed ones included
+	ListMyLoginHistory(context.Context, *ListMyLoginHistoryRequest) (*ListLoginHistoryResponse, error)
+	// ListMySessions List active sessions of the current user
+	ListMySessions(context.Context, *ListMySessionsRequest) (*ListSessionsResponse, error)
+	// ListUserSessions List active sessions of any user (session:admin)
+	ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListSessionsResponse, error)
 	// Login Login with email/username and password
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	// Logout Logout (revoke token)
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// OIDCCallback Complete an OpenID Connect login (provider redirect) and issue tokens like Login
+	OIDCCallback(context.Context, *OIDCCallbackRequest) (*LoginResponse, error)
 	// RefreshToken Refresh access token
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
 	// Register Register new user
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// RequestPasswordReset Email a password reset link (always succeeds)
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error)
+	// ResendVerificationEmail Send a new verification email (always succeeds)
+	ResendVerificationEmail(context.Context, *ResendVerificationEmailRequest) (*ResendVerificationEmailResponse, error)
+	// RevokeAPIKey Revoke an API key of the current user
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
 	// RevokeAllTokens Revoke all user tokens
 	RevokeAllTokens(context.Context, *RevokeAllTokensRequest) (*RevokeAllTokensResponse, error)
+	// RevokeSession Revoke one session of the current user
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	// RevokeUserSession Revoke one session of any user (session:admin)
+	RevokeUserSession(context.Context, *RevokeUserSessionRequest) (*RevokeSessionResponse, error)
+	// StartOIDCLogin Start a login at an external OpenID Connect provider
+	StartOIDCLogin(context.Context, *StartOIDCLoginRequest) (*StartOIDCLoginResponse, error)
+	// VerifyEmail Confirm the email address with the emailed verification token
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	// VerifyMFA Complete login with the MFA challenge token and a TOTP or recovery code
+	VerifyMFA(context.Context, *VerifyMFARequest) (*VerifyMFAResponse, error)
 }
 
 func RegisterAuthServiceHTTPServer(s *http.Server, srv AuthServiceHTTPServer) {
@@ -49,6 +113,27 @@ func RegisterAuthServiceHTTPServer(s *http.Server, srv AuthServiceHTTPServer) {
 	r.POST("/api/v1/auth/logout", _AuthService_Logout0_HTTP_Handler(srv))
 	r.GET("/api/v1/auth/me", _AuthService_GetCurrentUser0_HTTP_Handler(srv))
 	r.POST("/api/v1/auth/revoke-all", _AuthService_RevokeAllTokens0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/mfa/enroll", _AuthService_EnrollMFA0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/mfa/confirm", _AuthService_ConfirmMFAEnrollment0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/mfa/verify", _AuthService_VerifyMFA0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/mfa/disable", _AuthService_DisableMFA0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/password-reset/request", _AuthService_RequestPasswordReset0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/password-reset/confirm", _AuthService_ConfirmPasswordReset0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/verify-email", _AuthService_VerifyEmail0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/verify-email/resend", _AuthService_ResendVerificationEmail0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/password/change", _AuthService_ChangeMyPassword0_HTTP_Handler(srv))
+	r.GET("/api/v1/auth/oidc/{provider}/login", _AuthService_StartOIDCLogin0_HTTP_Handler(srv))
+	r.GET("/api/v1/auth/oidc/{provider}/callback", _AuthService_OIDCCallback0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/api-keys", _AuthService_CreateAPIKey0_HTTP_Handler(srv))
+	r.GET("/api/v1/auth/api-keys", _AuthService_ListAPIKeys0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/auth/api-keys/{id}", _AuthService_RevokeAPIKey0_HTTP_Handler(srv))
+	r.GET("/api/v1/auth/sessions", _AuthService_ListMySessions0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/auth/sessions/{id}", _AuthService_RevokeSession0_HTTP_Handler(srv))
+	r.GET("/api/v1/auth/login-history", _AuthService_ListMyLoginHistory0_HTTP_Handler(srv))
+	r.GET("/api/v1/auth/users/{user_id}/sessions", _AuthService_ListUserSessions0_HTTP_Handler(srv))
+	r.DELETE("/api/v1/auth/users/{user_id}/sessions/{id}", _AuthService_RevokeUserSession0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/users/{user_id}/impersonate", _AuthService_Impersonate0_HTTP_Handler(srv))
+	r.POST("/api/v1/auth/introspect", _AuthService_IntrospectToken0_HTTP_Handler(srv))
 }
 
 func _AuthService_Login0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
@@ -180,19 +265,518 @@ func _AuthService_RevokeAllTokens0_HTTP_Handler(srv AuthServiceHTTPServer) func(
 	}
 }
 
+func _AuthService_EnrollMFA0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in EnrollMFARequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceEnrollMFA)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.EnrollMFA(ctx, req.(*EnrollMFARequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*EnrollMFAResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ConfirmMFAEnrollment0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ConfirmMFAEnrollmentRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceConfirmMFAEnrollment)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ConfirmMFAEnrollment(ctx, req.(*ConfirmMFAEnrollmentRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfirmMFAEnrollmentResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_VerifyMFA0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in VerifyMFARequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceVerifyMFA)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.VerifyMFA(ctx, req.(*VerifyMFARequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*VerifyMFAResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_DisableMFA0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DisableMFARequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceDisableMFA)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DisableMFA(ctx, req.(*DisableMFARequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DisableMFAResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_RequestPasswordReset0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RequestPasswordResetRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceRequestPasswordReset)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RequestPasswordResetResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ConfirmPasswordReset0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ConfirmPasswordResetRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceConfirmPasswordReset)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ConfirmPasswordReset(ctx, req.(*ConfirmPasswordResetRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfirmPasswordResetResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_VerifyEmail0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in VerifyEmailRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceVerifyEmail)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.VerifyEmail(ctx, req.(*VerifyEmailRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*VerifyEmailResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ResendVerificationEmail0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ResendVerificationEmailRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceResendVerificationEmail)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResendVerificationEmail(ctx, req.(*ResendVerificationEmailRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ResendVerificationEmailResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ChangeMyPassword0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ChangeMyPasswordRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceChangeMyPassword)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ChangeMyPassword(ctx, req.(*ChangeMyPasswordRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ChangeMyPasswordResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_StartOIDCLogin0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in StartOIDCLoginRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceStartOIDCLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.StartOIDCLogin(ctx, req.(*StartOIDCLoginRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*StartOIDCLoginResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_OIDCCallback0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in OIDCCallbackRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceOIDCCallback)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.OIDCCallback(ctx, req.(*OIDCCallbackRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*LoginResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_CreateAPIKey0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateAPIKeyRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceCreateAPIKey)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateAPIKeyResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ListAPIKeys0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListAPIKeysRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceListAPIKeys)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListAPIKeysResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_RevokeAPIKey0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RevokeAPIKeyRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceRevokeAPIKey)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RevokeAPIKeyResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ListMySessions0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListMySessionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceListMySessions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListMySessions(ctx, req.(*ListMySessionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListSessionsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_RevokeSession0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RevokeSessionRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceRevokeSession)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RevokeSession(ctx, req.(*RevokeSessionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RevokeSessionResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ListMyLoginHistory0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListMyLoginHistoryRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceListMyLoginHistory)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListMyLoginHistory(ctx, req.(*ListMyLoginHistoryRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListLoginHistoryResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_ListUserSessions0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListUserSessionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceListUserSessions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListUserSessions(ctx, req.(*ListUserSessionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListSessionsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_RevokeUserSession0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RevokeUserSessionRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceRevokeUserSession)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RevokeUserSession(ctx, req.(*RevokeUserSessionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RevokeSessionResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_Impersonate0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImpersonateRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceImpersonate)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Impersonate(ctx, req.(*ImpersonateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ImpersonateResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _AuthService_IntrospectToken0_HTTP_Handler(srv AuthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in IntrospectTokenRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAuthServiceIntrospectToken)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.IntrospectToken(ctx, req.(*IntrospectTokenRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*IntrospectTokenResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type AuthServiceHTTPClient interface {
+	// ChangeMyPassword Change the password of the current user
+	ChangeMyPassword(ctx context.Context, req *ChangeMyPasswordRequest, opts ...http.CallOption) (rsp *ChangeMyPasswordResponse, err error)
+	// ConfirmMFAEnrollment Confirm TOTP enrollment with the first code, returns recovery codes
+	ConfirmMFAEnrollment(ctx context.Context, req *ConfirmMFAEnrollmentRequest, opts ...http.CallOption) (rsp *ConfirmMFAEnrollmentResponse, err error)
+	// ConfirmPasswordReset Set a new password with the emailed reset token
+	ConfirmPasswordReset(ctx context.Context, req *ConfirmPasswordResetRequest, opts ...http.CallOption) (rsp *ConfirmPasswordResetResponse, err error)
+	// CreateAPIKey Create an API key for the current user (the key is only returned once)
+	CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest, opts ...http.CallOption) (rsp *CreateAPIKeyResponse, err error)
+	// DisableMFA Disable TOTP for the current user
+	DisableMFA(ctx context.Context, req *DisableMFARequest, opts ...http.CallOption) (rsp *DisableMFAResponse, err error)
+	// EnrollMFA Start TOTP enrollment for the current user
+	EnrollMFA(ctx context.Context, req *EnrollMFARequest, opts ...http.CallOption) (rsp *EnrollMFAResponse, err error)
 	// GetCurrentUser Verify token and get current user
 	GetCurrentUser(ctx context.Context, req *GetCurrentUserRequest, opts ...http.CallOption) (rsp *GetCurrentUserResponse, err error)
+	// Impersonate Get a short-lived access token acting as another user (user:impersonate, audited)
+	Impersonate(ctx context.Context, req *ImpersonateRequest, opts ...http.CallOption) (rsp *ImpersonateResponse, err error)
+	// IntrospectToken RFC 7662 token introspection for trusted clients (HTTP Basic client credentials).
+	// Accepts access tokens, refresh tokens and API keys.
+	IntrospectToken(ctx context.Context, req *IntrospectTokenRequest, opts ...http.CallOption) (rsp *IntrospectTokenResponse, err error)
+	// ListAPIKeys List API keys of the current user
+	ListAPIKeys(ctx context.Context, req *ListAPIKeysRequest, opts ...http.CallOption) (rsp *ListAPIKeysResponse, err error)
+	// ListMyLoginHistory List recent login attempts of the current user, failed ones included
+	ListMyLoginHistory(ctx context.Context, req *ListMyLoginHistoryRequest, opts ...http.CallOption) (rsp *ListLoginHistoryResponse, err error)
+	// ListMySessions List active sessions of the current user
+	ListMySessions(ctx context.Context, req *ListMySessionsRequest, opts ...http.CallOption) (rsp *ListSessionsResponse, err error)
+	// ListUserSessions List active sessions of any user (session:admin)
+	ListUserSessions(ctx context.Context, req *ListUserSessionsRequest, opts ...http.CallOption) (rsp *ListSessionsResponse, err error)
 	// Login Login with email/username and password
 	Login(ctx context.Context, req *LoginRequest, opts ...http.CallOption) (rsp *LoginResponse, err error)
 	// Logout Logout (revoke token)
 	Logout(ctx context.Context, req *LogoutRequest, opts ...http.CallOption) (rsp *LogoutResponse, err error)
+	// OIDCCallback Complete an OpenID Connect login (provider redirect) and issue tokens like Login
+	OIDCCallback(ctx context.Context, req *OIDCCallbackRequest, opts ...http.CallOption) (rsp *LoginResponse, err error)
 	// RefreshToken Refresh access token
 	RefreshToken(ctx context.Context, req *RefreshTokenRequest, opts ...http.CallOption) (rsp *RefreshTokenResponse, err error)
 	// Register Register new user
 	Register(ctx context.Context, req *RegisterRequest, opts ...http.CallOption) (rsp *RegisterResponse, err error)
+	// RequestPasswordReset Email a password reset link (always succeeds)
+	RequestPasswordReset(ctx context.Context, req *RequestPasswordResetRequest, opts ...http.CallOption) (rsp *RequestPasswordResetResponse, err error)
+	// ResendVerificationEmail Send a new verification email (always succeeds)
+	ResendVerificationEmail(ctx context.Context, req *ResendVerificationEmailRequest, opts ...http.CallOption) (rsp *ResendVerificationEmailResponse, err error)
+	// RevokeAPIKey Revoke an API key of the current user
+	RevokeAPIKey(ctx context.Context, req *RevokeAPIKeyRequest, opts ...http.CallOption) (rsp *RevokeAPIKeyResponse, err error)
 	// RevokeAllTokens Revoke all user tokens
 	RevokeAllTokens(ctx context.Context, req *RevokeAllTokensRequest, opts ...http.CallOption) (rsp *RevokeAllTokensResponse, err error)
+	// RevokeSession Revoke one session of the current user
+	RevokeSession(ctx context.Context, req *RevokeSessionRequest, opts ...http.CallOption) (rsp *RevokeSessionResponse, err error)
+	// RevokeUserSession Revoke one session of any user (session:admin)
+	RevokeUserSession(ctx context.Context, req *RevokeUserSessionRequest, opts ...http.CallOption) (rsp *RevokeSessionResponse, err error)
+	// StartOIDCLogin Start a login at an external OpenID Connect provider
+	StartOIDCLogin(ctx context.Context, req *StartOIDCLoginRequest, opts ...http.CallOption) (rsp *StartOIDCLoginResponse, err error)
+	// VerifyEmail Confirm the email address with the emailed verification token
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest, opts ...http.CallOption) (rsp *VerifyEmailResponse, err error)
+	// VerifyMFA Complete login with the MFA challenge token and a TOTP or recovery code
+	VerifyMFA(ctx context.Context, req *VerifyMFARequest, opts ...http.CallOption) (rsp *VerifyMFAResponse, err error)
 }
 
 type AuthServiceHTTPClientImpl struct {
@@ -203,6 +787,90 @@ func NewAuthServiceHTTPClient(client *http.Client) AuthServiceHTTPClient {
 	return &AuthServiceHTTPClientImpl{client}
 }
 
+// ChangeMyPassword Change the password of the current user
+func (c *AuthServiceHTTPClientImpl) ChangeMyPassword(ctx context.Context, in *ChangeMyPasswordRequest, opts ...http.CallOption) (*ChangeMyPasswordResponse, error) {
+	var out ChangeMyPasswordResponse
+	pattern := "/api/v1/auth/password/change"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceChangeMyPassword))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmMFAEnrollment Confirm TOTP enrollment with the first code, returns recovery codes
+func (c *AuthServiceHTTPClientImpl) ConfirmMFAEnrollment(ctx context.Context, in *ConfirmMFAEnrollmentRequest, opts ...http.CallOption) (*ConfirmMFAEnrollmentResponse, error) {
+	var out ConfirmMFAEnrollmentResponse
+	pattern := "/api/v1/auth/mfa/confirm"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceConfirmMFAEnrollment))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmPasswordReset Set a new password with the emailed reset token
+func (c *AuthServiceHTTPClientImpl) ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest, opts ...http.CallOption) (*ConfirmPasswordResetResponse, error) {
+	var out ConfirmPasswordResetResponse
+	pattern := "/api/v1/auth/password-reset/confirm"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceConfirmPasswordReset))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAPIKey Create an API key for the current user (the key is only returned once)
+func (c *AuthServiceHTTPClientImpl) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...http.CallOption) (*CreateAPIKeyResponse, error) {
+	var out CreateAPIKeyResponse
+	pattern := "/api/v1/auth/api-keys"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceCreateAPIKey))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DisableMFA Disable TOTP for the current user
+func (c *AuthServiceHTTPClientImpl) DisableMFA(ctx context.Context, in *DisableMFARequest, opts ...http.CallOption) (*DisableMFAResponse, error) {
+	var out DisableMFAResponse
+	pattern := "/api/v1/auth/mfa/disable"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceDisableMFA))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EnrollMFA Start TOTP enrollment for the current user
+func (c *AuthServiceHTTPClientImpl) EnrollMFA(ctx context.Context, in *EnrollMFARequest, opts ...http.CallOption) (*EnrollMFAResponse, error) {
+	var out EnrollMFAResponse
+	pattern := "/api/v1/auth/mfa/enroll"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceEnrollMFA))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // GetCurrentUser Verify token and get current user
 func (c *AuthServiceHTTPClientImpl) GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...http.CallOption) (*GetCurrentUserResponse, error) {
 	var out GetCurrentUserResponse
@@ -217,6 +885,91 @@ func (c *AuthServiceHTTPClientImpl) GetCurrentUser(ctx context.Context, in *GetC
 	return &out, nil
 }
 
+// Impersonate Get a short-lived access token acting as another user (user:impersonate, audited)
+func (c *AuthServiceHTTPClientImpl) Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...http.CallOption) (*ImpersonateResponse, error) {
+	var out ImpersonateResponse
+	pattern := "/api/v1/auth/users/{user_id}/impersonate"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceImpersonate))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// IntrospectToken RFC 7662 token introspection for trusted clients (HTTP Basic client credentials).
+// Accepts access tokens, refresh tokens and API keys.
+func (c *AuthServiceHTTPClientImpl) IntrospectToken(ctx context.Context, in *IntrospectTokenRequest, opts ...http.CallOption) (*IntrospectTokenResponse, error) {
+	var out IntrospectTokenResponse
+	pattern := "/api/v1/auth/introspect"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceIntrospectToken))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAPIKeys List API keys of the current user
+func (c *AuthServiceHTTPClientImpl) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...http.CallOption) (*ListAPIKeysResponse, error) {
+	var out ListAPIKeysResponse
+	pattern := "/api/v1/auth/api-keys"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceListAPIKeys))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMyLoginHistory List recent login attempts of the current user, failed ones included
+func (c *AuthServiceHTTPClientImpl) ListMyLoginHistory(ctx context.Context, in *ListMyLoginHistoryRequest, opts ...http.CallOption) (*ListLoginHistoryResponse, error) {
+	var out ListLoginHistoryResponse
+	pattern := "/api/v1/auth/login-history"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceListMyLoginHistory))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMySessions List active sessions of the current user
+func (c *AuthServiceHTTPClientImpl) ListMySessions(ctx context.Context, in *ListMySessionsRequest, opts ...http.CallOption) (*ListSessionsResponse, error) {
+	var out ListSessionsResponse
+	pattern := "/api/v1/auth/sessions"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceListMySessions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListUserSessions List active sessions of any user (session:admin)
+func (c *AuthServiceHTTPClientImpl) ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...http.CallOption) (*ListSessionsResponse, error) {
+	var out ListSessionsResponse
+	pattern := "/api/v1/auth/users/{user_id}/sessions"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceListUserSessions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // Login Login with email/username and password
 func (c *AuthServiceHTTPClientImpl) Login(ctx context.Context, in *LoginRequest, opts ...http.CallOption) (*LoginResponse, error) {
 	var out LoginResponse
@@ -245,6 +998,20 @@ func (c *AuthServiceHTTPClientImpl) Logout(ctx context.Context, in *LogoutReques
 	return &out, nil
 }
 
+// OIDCCallback Complete an OpenID Connect login (provider redirect) and issue tokens like Login
+func (c *AuthServiceHTTPClientImpl) OIDCCallback(ctx context.Context, in *OIDCCallbackRequest, opts ...http.CallOption) (*LoginResponse, error) {
+	var out LoginResponse
+	pattern := "/api/v1/auth/oidc/{provider}/callback"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceOIDCCallback))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // RefreshToken Refresh access token
 func (c *AuthServiceHTTPClientImpl) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...http.CallOption) (*RefreshTokenResponse, error) {
 	var out RefreshTokenResponse
@@ -273,6 +1040,48 @@ func (c *AuthServiceHTTPClientImpl) Register(ctx context.Context, in *RegisterRe
 	return &out, nil
 }
 
+// RequestPasswordReset Email a password reset link (always succeeds)
+func (c *AuthServiceHTTPClientImpl) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...http.CallOption) (*RequestPasswordResetResponse, error) {
+	var out RequestPasswordResetResponse
+	pattern := "/api/v1/auth/password-reset/request"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceRequestPasswordReset))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ResendVerificationEmail Send a new verification email (always succeeds)
+func (c *AuthServiceHTTPClientImpl) ResendVerificationEmail(ctx context.Context, in *ResendVerificationEmailRequest, opts ...http.CallOption) (*ResendVerificationEmailResponse, error) {
+	var out ResendVerificationEmailResponse
+	pattern := "/api/v1/auth/verify-email/resend"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceResendVerificationEmail))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RevokeAPIKey Revoke an API key of the current user
+func (c *AuthServiceHTTPClientImpl) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...http.CallOption) (*RevokeAPIKeyResponse, error) {
+	var out RevokeAPIKeyResponse
+	pattern := "/api/v1/auth/api-keys/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceRevokeAPIKey))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // RevokeAllTokens Revoke all user tokens
 func (c *AuthServiceHTTPClientImpl) RevokeAllTokens(ctx context.Context, in *RevokeAllTokensRequest, opts ...http.CallOption) (*RevokeAllTokensResponse, error) {
 	var out RevokeAllTokensResponse
@@ -286,3 +1095,73 @@ func (c *AuthServiceHTTPClientImpl) RevokeAllTokens(ctx context.Context, in *Rev
 	}
 	return &out, nil
 }
+
+// RevokeSession Revoke one session of the current user
+func (c *AuthServiceHTTPClientImpl) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...http.CallOption) (*RevokeSessionResponse, error) {
+	var out RevokeSessionResponse
+	pattern := "/api/v1/auth/sessions/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceRevokeSession))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RevokeUserSession Revoke one session of any user (session:admin)
+func (c *AuthServiceHTTPClientImpl) RevokeUserSession(ctx context.Context, in *RevokeUserSessionRequest, opts ...http.CallOption) (*RevokeSessionResponse, error) {
+	var out RevokeSessionResponse
+	pattern := "/api/v1/auth/users/{user_id}/sessions/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceRevokeUserSession))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StartOIDCLogin Start a login at an external OpenID Connect provider
+func (c *AuthServiceHTTPClientImpl) StartOIDCLogin(ctx context.Context, in *StartOIDCLoginRequest, opts ...http.CallOption) (*StartOIDCLoginResponse, error) {
+	var out StartOIDCLoginResponse
+	pattern := "/api/v1/auth/oidc/{provider}/login"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAuthServiceStartOIDCLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyEmail Confirm the email address with the emailed verification token
+func (c *AuthServiceHTTPClientImpl) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...http.CallOption) (*VerifyEmailResponse, error) {
+	var out VerifyEmailResponse
+	pattern := "/api/v1/auth/verify-email"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceVerifyEmail))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyMFA Complete login with the MFA challenge token and a TOTP or recovery code
+func (c *AuthServiceHTTPClientImpl) VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...http.CallOption) (*VerifyMFAResponse, error) {
+	var out VerifyMFAResponse
+	pattern := "/api/v1/auth/mfa/verify"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAuthServiceVerifyMFA))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -24,31 +24,103 @@ const (
 type ErrorReason int32
 
 const (
-	ErrorReason_AUTH_UNSPECIFIED    ErrorReason = 0
-	ErrorReason_INVALID_CREDENTIALS ErrorReason = 1
-	ErrorReason_TOKEN_EXPIRED       ErrorReason = 2
-	ErrorReason_TOKEN_INVALID       ErrorReason = 3
-	ErrorReason_TOKEN_REVOKED       ErrorReason = 4
-	ErrorReason_USER_INACTIVE       ErrorReason = 5
+	ErrorReason_AUTH_UNSPECIFIED           ErrorReason = 0
+	ErrorReason_INVALID_CREDENTIALS        ErrorReason = 1
+	ErrorReason_TOKEN_EXPIRED              ErrorReason = 2
+	ErrorReason_TOKEN_INVALID              ErrorReason = 3
+	ErrorReason_TOKEN_REVOKED              ErrorReason = 4
+	ErrorReason_USER_INACTIVE              ErrorReason = 5
+	ErrorReason_TOKEN_REUSED               ErrorReason = 6
+	ErrorReason_MFA_INVALID_CODE           ErrorReason = 7
+	ErrorReason_MFA_NOT_ENROLLED           ErrorReason = 8
+	ErrorReason_MFA_ALREADY_ENABLED        ErrorReason = 9
+	ErrorReason_RESET_TOKEN_INVALID        ErrorReason = 10
+	ErrorReason_EMAIL_NOT_VERIFIED         ErrorReason = 11
+	ErrorReason_VERIFICATION_TOKEN_INVALID ErrorReason = 12
+	ErrorReason_ACCOUNT_LOCKED             ErrorReason = 13
+	ErrorReason_SESSION_NOT_FOUND          ErrorReason = 14
+	ErrorReason_API_KEY_INVALID            ErrorReason = 15
+	ErrorReason_API_KEY_NOT_FOUND          ErrorReason = 16
+	ErrorReason_API_KEY_SCOPE_DENIED       ErrorReason = 17
+	ErrorReason_OIDC_PROVIDER_NOT_FOUND    ErrorReason = 18
+	ErrorReason_OIDC_STATE_INVALID         ErrorReason = 19
+	ErrorReason_OIDC_LOGIN_FAILED          ErrorReason = 20
+	ErrorReason_OIDC_ACCOUNT_EXISTS        ErrorReason = 21
+	ErrorReason_OIDC_USER_NOT_PROVISIONED  ErrorReason = 22
+	ErrorReason_PASSWORD_REUSED            ErrorReason = 23
+	ErrorReason_PASSWORD_BREACHED          ErrorReason = 24
+	ErrorReason_IMPERSONATION_NOT_ALLOWED  ErrorReason = 25
+	ErrorReason_IMPERSONATION_RESTRICTED   ErrorReason = 26
+	ErrorReason_INVALID_CLIENT             ErrorReason = 27
+	ErrorReason_INVALID_TENANT             ErrorReason = 28
+	ErrorReason_TENANT_MISMATCH            ErrorReason = 29
 )
 
 // Enum value maps for ErrorReason.
 var (
 	ErrorReason_name = map[int32]string{
-		0: "AUTH_UNSPECIFIED",
-		1: "INVALID_CREDENTIALS",
-		2: "TOKEN_EXPIRED",
-		3: "TOKEN_INVALID",
-		4: "TOKEN_REVOKED",
-		5: "USER_INACTIVE",
+		0:  "AUTH_UNSPECIFIED",
+		1:  "INVALID_CREDENTIALS",
+		2:  "TOKEN_EXPIRED",
+		3:  "TOKEN_INVALID",
+		4:  "TOKEN_REVOKED",
+		5:  "USER_INACTIVE",
+		6:  "TOKEN_REUSED",
+		7:  "MFA_INVALID_CODE",
+		8:  "MFA_NOT_ENROLLED",
+		9:  "MFA_ALREADY_ENABLED",
+		10: "RESET_TOKEN_INVALID",
+		11: "EMAIL_NOT_VERIFIED",
+		12: "VERIFICATION_TOKEN_INVALID",
+		13: "ACCOUNT_LOCKED",
+		14: "SESSION_NOT_FOUND",
+		15: "API_KEY_INVALID",
+		16: "API_KEY_NOT_FOUND",
+		17: "API_KEY_SCOPE_DENIED",
+		18: "OIDC_PROVIDER_NOT_FOUND",
+		19: "OIDC_STATE_INVALID",
+		20: "OIDC_LOGIN_FAILED",
+		21: "OIDC_ACCOUNT_EXISTS",
+		22: "OIDC_USER_NOT_PROVISIONED",
+		23: "PASSWORD_REUSED",
+		24: "PASSWORD_BREACHED",
+		25: "IMPERSONATION_NOT_ALLOWED",
+		26: "IMPERSONATION_RESTRICTED",
+		27: "INVALID_CLIENT",
+		28: "INVALID_TENANT",
+		29: "TENANT_MISMATCH",
 	}
 	ErrorReason_value = map[string]int32{
-		"AUTH_UNSPECIFIED":    0,
-		"INVALID_CREDENTIALS": 1,
-		"TOKEN_EXPIRED":       2,
-		"TOKEN_INVALID":       3,
-		"TOKEN_REVOKED":       4,
-		"USER_INACTIVE":       5,
+		"AUTH_UNSPECIFIED":           0,
+		"INVALID_CREDENTIALS":        1,
+		"TOKEN_EXPIRED":              2,
+		"TOKEN_INVALID":              3,
+		"TOKEN_REVOKED":              4,
+		"USER_INACTIVE":              5,
+		"TOKEN_REUSED":               6,
+		"MFA_INVALID_CODE":           7,
+		"MFA_NOT_ENROLLED":           8,
+		"MFA_ALREADY_ENABLED":        9,
+		"RESET_TOKEN_INVALID":        10,
+		"EMAIL_NOT_VERIFIED":         11,
+		"VERIFICATION_TOKEN_INVALID": 12,
+		"ACCOUNT_LOCKED":             13,
+		"SESSION_NOT_FOUND":          14,
+		"API_KEY_INVALID":            15,
+		"API_KEY_NOT_FOUND":          16,
+		"API_KEY_SCOPE_DENIED":       17,
+		"OIDC_PROVIDER_NOT_FOUND":    18,
+		"OIDC_STATE_INVALID":         19,
+		"OIDC_LOGIN_FAILED":          20,
+		"OIDC_ACCOUNT_EXISTS":        21,
+		"OIDC_USER_NOT_PROVISIONED":  22,
+		"PASSWORD_REUSED":            23,
+		"PASSWORD_BREACHED":          24,
+		"IMPERSONATION_NOT_ALLOWED":  25,
+		"IMPERSONATION_RESTRICTED":   26,
+		"INVALID_CLIENT":             27,
+		"INVALID_TENANT":             28,
+		"TENANT_MISMATCH":            29,
 	}
 )
 
@@ -83,14 +155,39 @@ var File_auth_v1_error_reason_proto protoreflect.FileDescriptor
 
 const file_auth_v1_error_reason_proto_rawDesc = "" +
 	"\n" +
-	"\x1aauth/v1/error_reason.proto\x12\aauth.v1*\x88\x01\n" +
+	"\x1aauth/v1/error_reason.proto\x12\aauth.v1*\xcb\x05\n" +
 	"\vErrorReason\x12\x14\n" +
 	"\x10AUTH_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13INVALID_CREDENTIALS\x10\x01\x12\x11\n" +
 	"\rTOKEN_EXPIRED\x10\x02\x12\x11\n" +
 	"\rTOKEN_INVALID\x10\x03\x12\x11\n" +
 	"\rTOKEN_REVOKED\x10\x04\x12\x11\n" +
-	"\rUSER_INACTIVE\x10\x05B3Z1github.com/go-kratos/kratos-layout/api/auth/v1;v1b\x06proto3"
+	"\rUSER_INACTIVE\x10\x05\x12\x10\n" +
+	"\fTOKEN_REUSED\x10\x06\x12\x14\n" +
+	"\x10MFA_INVALID_CODE\x10\a\x12\x14\n" +
+	"\x10MFA_NOT_ENROLLED\x10\b\x12\x17\n" +
+	"\x13MFA_ALREADY_ENABLED\x10\t\x12\x17\n" +
+	"\x13RESET_TOKEN_INVALID\x10\n" +
+	"\x12\x16\n" +
+	"\x12EMAIL_NOT_VERIFIED\x10\v\x12\x1e\n" +
+	"\x1aVERIFICATION_TOKEN_INVALID\x10\f\x12\x12\n" +
+	"\x0eACCOUNT_LOCKED\x10\r\x12\x15\n" +
+	"\x11SESSION_NOT_FOUND\x10\x0e\x12\x13\n" +
+	"\x0fAPI_KEY_INVALID\x10\x0f\x12\x15\n" +
+	"\x11API_KEY_NOT_FOUND\x10\x10\x12\x18\n" +
+	"\x14API_KEY_SCOPE_DENIED\x10\x11\x12\x1b\n" +
+	"\x17OIDC_PROVIDER_NOT_FOUND\x10\x12\x12\x16\n" +
+	"\x12OIDC_STATE_INVALID\x10\x13\x12\x15\n" +
+	"\x11OIDC_LOGIN_FAILED\x10\x14\x12\x17\n" +
+	"\x13OIDC_ACCOUNT_EXISTS\x10\x15\x12\x1d\n" +
+	"\x19OIDC_USER_NOT_PROVISIONED\x10\x16\x12\x13\n" +
+	"\x0fPASSWORD_REUSED\x10\x17\x12\x15\n" +
+	"\x11PASSWORD_BREACHED\x10\x18\x12\x1d\n" +
+	"\x19IMPERSONATION_NOT_ALLOWED\x10\x19\x12\x1c\n" +
+	"\x18IMPERSONATION_RESTRICTED\x10\x1a\x12\x12\n" +
+	"\x0eINVALID_CLIENT\x10\x1b\x12\x12\n" +
+	"\x0eINVALID_TENANT\x10\x1c\x12\x13\n" +
+	"\x0fTENANT_MISMATCH\x10\x1dB3Z1github.com/go-kratos/kratos-layout/api/auth/v1;v1b\x06proto3"
 
 var (
 	file_auth_v1_error_reason_proto_rawDescOnce sync.Once
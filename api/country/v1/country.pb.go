@@ -7,6 +7,7 @@
 package v1
 
 import (
+	_ "github.com/go-kratos/kratos-layout/api/authz"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -1096,7 +1097,7 @@ var File_country_v1_country_proto protoreflect.FileDescriptor
 const file_country_v1_country_proto_rawDesc = "" +
 	"\n" +
 	"\x18country/v1/country.proto\x12\n" +
-	"country.v1\x1a\x1cgoogle/api/annotations.proto\"\xb6\x03\n" +
+	"country.v1\x1a\x11authz/authz.proto\x1a\x1cgoogle/api/annotations.proto\"\xb6\x03\n" +
 	"\x14CreateCountryRequest\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
@@ -1194,16 +1195,16 @@ const file_country_v1_country_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x11 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x12 \x01(\tR\tupdatedAt2\xd6\x06\n" +
-	"\x0eCountryService\x12r\n" +
-	"\rCreateCountry\x12 .country.v1.CreateCountryRequest\x1a!.country.v1.CreateCountryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/countries\x12w\n" +
-	"\rUpdateCountry\x12 .country.v1.UpdateCountryRequest\x1a!.country.v1.UpdateCountryResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/api/v1/countries/{id}\x12t\n" +
-	"\rDeleteCountry\x12 .country.v1.DeleteCountryRequest\x1a!.country.v1.DeleteCountryResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/api/v1/countries/{id}\x12k\n" +
+	"updated_at\x18\x12 \x01(\tR\tupdatedAt2\xdc\a\n" +
+	"\x0eCountryService\x12\x85\x01\n" +
+	"\rCreateCountry\x12 .country.v1.CreateCountryRequest\x1a!.country.v1.CreateCountryResponse\"/\xca\xf3\x18\x0f\x1a\rcountry:write\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/countries\x12\x8a\x01\n" +
+	"\rUpdateCountry\x12 .country.v1.UpdateCountryRequest\x1a!.country.v1.UpdateCountryResponse\"4\xca\xf3\x18\x0f\x1a\rcountry:write\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/api/v1/countries/{id}\x12\x87\x01\n" +
+	"\rDeleteCountry\x12 .country.v1.DeleteCountryRequest\x1a!.country.v1.DeleteCountryResponse\"1\xca\xf3\x18\x0f\x1a\rcountry:write\x82\xd3\xe4\x93\x02\x18*\x16/api/v1/countries/{id}\x12}\n" +
 	"\n" +
-	"GetCountry\x12\x1d.country.v1.GetCountryRequest\x1a\x1e.country.v1.GetCountryResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/countries/{id}\x12\x84\x01\n" +
-	"\x10GetCountryByCode\x12#.country.v1.GetCountryByCodeRequest\x1a$.country.v1.GetCountryByCodeResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/countries/code/{code}\x12o\n" +
-	"\rListCountries\x12 .country.v1.ListCountriesRequest\x1a!.country.v1.ListCountriesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/countries\x12|\n" +
-	"\x0fSearchCountries\x12\".country.v1.SearchCountriesRequest\x1a#.country.v1.SearchCountriesResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/countries/searchB6Z4github.com/go-kratos/kratos-layout/api/country/v1;v1b\x06proto3"
+	"GetCountry\x12\x1d.country.v1.GetCountryRequest\x1a\x1e.country.v1.GetCountryResponse\"0\xca\xf3\x18\x0e\x1a\fcountry:read\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/countries/{id}\x12\x96\x01\n" +
+	"\x10GetCountryByCode\x12#.country.v1.GetCountryByCodeRequest\x1a$.country.v1.GetCountryByCodeResponse\"7\xca\xf3\x18\x0e\x1a\fcountry:read\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/countries/code/{code}\x12\x81\x01\n" +
+	"\rListCountries\x12 .country.v1.ListCountriesRequest\x1a!.country.v1.ListCountriesResponse\"+\xca\xf3\x18\x0e\x1a\fcountry:read\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/countries\x12\x8e\x01\n" +
+	"\x0fSearchCountries\x12\".country.v1.SearchCountriesRequest\x1a#.country.v1.SearchCountriesResponse\"2\xca\xf3\x18\x0e\x1a\fcountry:read\x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/countries/searchB6Z4github.com/go-kratos/kratos-layout/api/country/v1;v1b\x06proto3"
 
 var (
 	file_country_v1_country_proto_rawDescOnce sync.Once
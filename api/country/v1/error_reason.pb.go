@@ -28,6 +28,7 @@ const (
 	ErrorReason_COUNTRY_NOT_FOUND      ErrorReason = 1
 	ErrorReason_COUNTRY_ALREADY_EXISTS ErrorReason = 2
 	ErrorReason_INVALID_COUNTRY_CODE   ErrorReason = 3
+	ErrorReason_COUNTRY_SHARED         ErrorReason = 4
 )
 
 // Enum value maps for ErrorReason.
@@ -37,12 +38,14 @@ var (
 		1: "COUNTRY_NOT_FOUND",
 		2: "COUNTRY_ALREADY_EXISTS",
 		3: "INVALID_COUNTRY_CODE",
+		4: "COUNTRY_SHARED",
 	}
 	ErrorReason_value = map[string]int32{
 		"COUNTRY_UNSPECIFIED":    0,
 		"COUNTRY_NOT_FOUND":      1,
 		"COUNTRY_ALREADY_EXISTS": 2,
 		"INVALID_COUNTRY_CODE":   3,
+		"COUNTRY_SHARED":         4,
 	}
 )
 
@@ -78,12 +81,13 @@ var File_country_v1_error_reason_proto protoreflect.FileDescriptor
 const file_country_v1_error_reason_proto_rawDesc = "" +
 	"\n" +
 	"\x1dcountry/v1/error_reason.proto\x12\n" +
-	"country.v1*s\n" +
+	"country.v1*\x87\x01\n" +
 	"\vErrorReason\x12\x17\n" +
 	"\x13COUNTRY_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11COUNTRY_NOT_FOUND\x10\x01\x12\x1a\n" +
 	"\x16COUNTRY_ALREADY_EXISTS\x10\x02\x12\x18\n" +
-	"\x14INVALID_COUNTRY_CODE\x10\x03B6Z4github.com/go-kratos/kratos-layout/api/country/v1;v1b\x06proto3"
+	"\x14INVALID_COUNTRY_CODE\x10\x03\x12\x12\n" +
+	"\x0eCOUNTRY_SHARED\x10\x04B6Z4github.com/go-kratos/kratos-layout/api/country/v1;v1b\x06proto3"
 
 var (
 	file_country_v1_error_reason_proto_rawDescOnce sync.Once
@@ -7,6 +7,7 @@
 package v1
 
 import (
+	_ "github.com/go-kratos/kratos-layout/api/authz"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -1079,7 +1080,7 @@ var File_province_v1_province_proto protoreflect.FileDescriptor
 
 const file_province_v1_province_proto_rawDesc = "" +
 	"\n" +
-	"\x1aprovince/v1/province.proto\x12\vprovince.v1\x1a\x1cgoogle/api/annotations.proto\"\xde\x02\n" +
+	"\x1aprovince/v1/province.proto\x12\vprovince.v1\x1a\x11authz/authz.proto\x1a\x1cgoogle/api/annotations.proto\"\xde\x02\n" +
 	"\x15CreateProvinceRequest\x12\x1d\n" +
 	"\n" +
 	"country_id\x18\x01 \x01(\tR\tcountryId\x12\x12\n" +
@@ -1154,7 +1155,7 @@ const file_province_v1_province_proto_rawDesc = "" +
 	"\n" +
 	"country_id\x18\x01 \x01(\tR\tcountryId\"U\n" +
 	"\x1eListProvincesByCountryResponse\x123\n" +
-	"\tprovinces\x18\x01 \x03(\v2\x15.province.v1.ProvinceR\tprovinces\"\xf5\x03\n" +
+	"\tprovinces\x18\x01 \x03(\v2\x15.province.v1.ProvinceR\tprovinces\"\xa9\x04\n" +
 	"\bProvince\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1179,19 +1180,24 @@ const file_province_v1_province_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0f \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x10 \x01(\tR\tupdatedAt\x12\x1d\n" +
+	"updated_at\x18\x10 \x01(\tR\tupdatedAt\x12/\n" +
 	"\n" +
-	"created_by\x18\x11 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"created_by\x18\x11 \x01(\tB\x10\xda\xf3\x18\f\n" +
 	"\n" +
-	"updated_by\x18\x12 \x01(\tR\tupdatedBy2\x9a\a\n" +
-	"\x0fProvinceService\x12w\n" +
-	"\x0eCreateProvince\x12\".province.v1.CreateProvinceRequest\x1a#.province.v1.CreateProvinceResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/provinces\x12|\n" +
-	"\x0eUpdateProvince\x12\".province.v1.UpdateProvinceRequest\x1a#.province.v1.UpdateProvinceResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/api/v1/provinces/{id}\x12y\n" +
-	"\x0eDeleteProvince\x12\".province.v1.DeleteProvinceRequest\x1a#.province.v1.DeleteProvinceResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/api/v1/provinces/{id}\x12p\n" +
-	"\vGetProvince\x12\x1f.province.v1.GetProvinceRequest\x1a .province.v1.GetProvinceResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/provinces/{id}\x12\x89\x01\n" +
-	"\x11GetProvinceByCode\x12%.province.v1.GetProvinceByCodeRequest\x1a&.province.v1.GetProvinceByCodeResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/provinces/code/{code}\x12q\n" +
-	"\rListProvinces\x12!.province.v1.ListProvincesRequest\x1a\".province.v1.ListProvincesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/provinces\x12\xa3\x01\n" +
-	"\x16ListProvincesByCountry\x12*.province.v1.ListProvincesByCountryRequest\x1a+.province.v1.ListProvincesByCountryResponse\"0\x82\xd3\xe4\x93\x02*\x12(/api/v1/countries/{country_id}/provincesB7Z5github.com/go-kratos/kratos-layout/api/province/v1;v1b\x06proto3"
+	"audit:readR\tcreatedBy\x12/\n" +
+	"\n" +
+	"updated_by\x18\x12 \x01(\tB\x10\xda\xf3\x18\f\n" +
+	"\n" +
+	"audit:readR\tupdatedBy:\x0e\xd2\xf3\x18\n" +
+	"created_by2\xa7\b\n" +
+	"\x0fProvinceService\x12\x8b\x01\n" +
+	"\x0eCreateProvince\x12\".province.v1.CreateProvinceRequest\x1a#.province.v1.CreateProvinceResponse\"0\xca\xf3\x18\x10\x1a\x0eprovince:write\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/provinces\x12\x90\x01\n" +
+	"\x0eUpdateProvince\x12\".province.v1.UpdateProvinceRequest\x1a#.province.v1.UpdateProvinceResponse\"5\xca\xf3\x18\x10\x1a\x0eprovince:write\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/api/v1/provinces/{id}\x12\x8d\x01\n" +
+	"\x0eDeleteProvince\x12\".province.v1.DeleteProvinceRequest\x1a#.province.v1.DeleteProvinceResponse\"2\xca\xf3\x18\x10\x1a\x0eprovince:write\x82\xd3\xe4\x93\x02\x18*\x16/api/v1/provinces/{id}\x12\x83\x01\n" +
+	"\vGetProvince\x12\x1f.province.v1.GetProvinceRequest\x1a .province.v1.GetProvinceResponse\"1\xca\xf3\x18\x0f\x1a\rprovince:read\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/provinces/{id}\x12\x9c\x01\n" +
+	"\x11GetProvinceByCode\x12%.province.v1.GetProvinceByCodeRequest\x1a&.province.v1.GetProvinceByCodeResponse\"8\xca\xf3\x18\x0f\x1a\rprovince:read\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/provinces/code/{code}\x12\x84\x01\n" +
+	"\rListProvinces\x12!.province.v1.ListProvincesRequest\x1a\".province.v1.ListProvincesResponse\",\xca\xf3\x18\x0f\x1a\rprovince:read\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/provinces\x12\xb6\x01\n" +
+	"\x16ListProvincesByCountry\x12*.province.v1.ListProvincesByCountryRequest\x1a+.province.v1.ListProvincesByCountryResponse\"C\xca\xf3\x18\x0f\x1a\rprovince:read\x82\xd3\xe4\x93\x02*\x12(/api/v1/countries/{country_id}/provincesB7Z5github.com/go-kratos/kratos-layout/api/province/v1;v1b\x06proto3"
 
 var (
 	file_province_v1_province_proto_rawDescOnce sync.Once
@@ -28,6 +28,8 @@ const (
 	ErrorReason_USER_NOT_FOUND      ErrorReason = 1
 	ErrorReason_USER_ALREADY_EXISTS ErrorReason = 2
 	ErrorReason_INVALID_PASSWORD    ErrorReason = 3
+	ErrorReason_FORBIDDEN           ErrorReason = 4
+	ErrorReason_INVALID_STATUS      ErrorReason = 5
 )
 
 // Enum value maps for ErrorReason.
@@ -37,12 +39,16 @@ var (
 		1: "USER_NOT_FOUND",
 		2: "USER_ALREADY_EXISTS",
 		3: "INVALID_PASSWORD",
+		4: "FORBIDDEN",
+		5: "INVALID_STATUS",
 	}
 	ErrorReason_value = map[string]int32{
 		"USER_UNSPECIFIED":    0,
 		"USER_NOT_FOUND":      1,
 		"USER_ALREADY_EXISTS": 2,
 		"INVALID_PASSWORD":    3,
+		"FORBIDDEN":           4,
+		"INVALID_STATUS":      5,
 	}
 )
 
@@ -77,12 +83,14 @@ var File_user_v1_error_reason_proto protoreflect.FileDescriptor
 
 const file_user_v1_error_reason_proto_rawDesc = "" +
 	"\n" +
-	"\x1auser/v1/error_reason.proto\x12\auser.v1*f\n" +
+	"\x1auser/v1/error_reason.proto\x12\auser.v1*\x89\x01\n" +
 	"\vErrorReason\x12\x14\n" +
 	"\x10USER_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eUSER_NOT_FOUND\x10\x01\x12\x17\n" +
 	"\x13USER_ALREADY_EXISTS\x10\x02\x12\x14\n" +
-	"\x10INVALID_PASSWORD\x10\x03B3Z1github.com/go-kratos/kratos-layout/api/user/v1;v1b\x06proto3"
+	"\x10INVALID_PASSWORD\x10\x03\x12\r\n" +
+	"\tFORBIDDEN\x10\x04\x12\x12\n" +
+	"\x0eINVALID_STATUS\x10\x05B3Z1github.com/go-kratos/kratos-layout/api/user/v1;v1b\x06proto3"
 
 var (
 	file_user_v1_error_reason_proto_rawDescOnce sync.Once
@@ -26,18 +26,25 @@ const OperationUserServiceGetUser = "/user.v1.UserService/GetUser"
 const OperationUserServiceGetUserByEmail = "/user.v1.UserService/GetUserByEmail"
 const OperationUserServiceGetUserByUsername = "/user.v1.UserService/GetUserByUsername"
 const OperationUserServiceListUsers = "/user.v1.UserService/ListUsers"
+const OperationUserServiceUnlockUser = "/user.v1.UserService/UnlockUser"
 const OperationUserServiceUpdateUser = "/user.v1.UserService/UpdateUser"
 
 type UserServiceHTTPServer interface {
+	// ChangePassword Reset the password of any user (user:admin, audited).
+	// Users change their own password with AuthService.ChangeMyPassword.
 	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
 	// CreateUser Commands
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	// GetUser Queries
+	// Users may read their own record; others need user:read (checked in UserUsecase)
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
 	GetUserByEmail(context.Context, *GetUserByEmailRequest) (*GetUserByEmailResponse, error)
 	GetUserByUsername(context.Context, *GetUserByUsernameRequest) (*GetUserByUsernameResponse, error)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// UnlockUser Clear the failed-login lock of an account (user:admin)
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	// UpdateUser Users may update their own record; others need user:write (checked in UserUsecase)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 }
 
@@ -47,6 +54,7 @@ func RegisterUserServiceHTTPServer(s *http.Server, srv UserServiceHTTPServer) {
 	r.PUT("/api/v1/users/{id}", _UserService_UpdateUser0_HTTP_Handler(srv))
 	r.DELETE("/api/v1/users/{id}", _UserService_DeleteUser0_HTTP_Handler(srv))
 	r.POST("/api/v1/users/{id}/change-password", _UserService_ChangePassword0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/{id}/unlock", _UserService_UnlockUser0_HTTP_Handler(srv))
 	r.GET("/api/v1/users/{id}", _UserService_GetUser0_HTTP_Handler(srv))
 	r.GET("/api/v1/users/email/{email}", _UserService_GetUserByEmail0_HTTP_Handler(srv))
 	r.GET("/api/v1/users/username/{username}", _UserService_GetUserByUsername0_HTTP_Handler(srv))
@@ -147,6 +155,31 @@ func _UserService_ChangePassword0_HTTP_Handler(srv UserServiceHTTPServer) func(c
 	}
 }
 
+func _UserService_UnlockUser0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UnlockUserRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceUnlockUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UnlockUser(ctx, req.(*UnlockUserRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UnlockUserResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _UserService_GetUser0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in GetUserRequest
@@ -233,15 +266,21 @@ func _UserService_ListUsers0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx ht
 }
 
 type UserServiceHTTPClient interface {
+	// ChangePassword Reset the password of any user (user:admin, audited).
+	// Users change their own password with AuthService.ChangeMyPassword.
 	ChangePassword(ctx context.Context, req *ChangePasswordRequest, opts ...http.CallOption) (rsp *ChangePasswordResponse, err error)
 	// CreateUser Commands
 	CreateUser(ctx context.Context, req *CreateUserRequest, opts ...http.CallOption) (rsp *CreateUserResponse, err error)
 	DeleteUser(ctx context.Context, req *DeleteUserRequest, opts ...http.CallOption) (rsp *DeleteUserResponse, err error)
 	// GetUser Queries
+	// Users may read their own record; others need user:read (checked in UserUsecase)
 	GetUser(ctx context.Context, req *GetUserRequest, opts ...http.CallOption) (rsp *GetUserResponse, err error)
 	GetUserByEmail(ctx context.Context, req *GetUserByEmailRequest, opts ...http.CallOption) (rsp *GetUserByEmailResponse, err error)
 	GetUserByUsername(ctx context.Context, req *GetUserByUsernameRequest, opts ...http.CallOption) (rsp *GetUserByUsernameResponse, err error)
 	ListUsers(ctx context.Context, req *ListUsersRequest, opts ...http.CallOption) (rsp *ListUsersResponse, err error)
+	// UnlockUser Clear the failed-login lock of an account (user:admin)
+	UnlockUser(ctx context.Context, req *UnlockUserRequest, opts ...http.CallOption) (rsp *UnlockUserResponse, err error)
+	// UpdateUser Users may update their own record; others need user:write (checked in UserUsecase)
 	UpdateUser(ctx context.Context, req *UpdateUserRequest, opts ...http.CallOption) (rsp *UpdateUserResponse, err error)
 }
 
@@ -253,6 +292,8 @@ func NewUserServiceHTTPClient(client *http.Client) UserServiceHTTPClient {
 	return &UserServiceHTTPClientImpl{client}
 }
 
+// ChangePassword Reset the password of any user (user:admin, audited).
+// Users change their own password with AuthService.ChangeMyPassword.
 func (c *UserServiceHTTPClientImpl) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...http.CallOption) (*ChangePasswordResponse, error) {
 	var out ChangePasswordResponse
 	pattern := "/api/v1/users/{id}/change-password"
@@ -294,6 +335,7 @@ func (c *UserServiceHTTPClientImpl) DeleteUser(ctx context.Context, in *DeleteUs
 }
 
 // GetUser Queries
+// Users may read their own record; others need user:read (checked in UserUsecase)
 func (c *UserServiceHTTPClientImpl) GetUser(ctx context.Context, in *GetUserRequest, opts ...http.CallOption) (*GetUserResponse, error) {
 	var out GetUserResponse
 	pattern := "/api/v1/users/{id}"
@@ -346,6 +388,21 @@ func (c *UserServiceHTTPClientImpl) ListUsers(ctx context.Context, in *ListUsers
 	return &out, nil
 }
 
+// UnlockUser Clear the failed-login lock of an account (user:admin)
+func (c *UserServiceHTTPClientImpl) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...http.CallOption) (*UnlockUserResponse, error) {
+	var out UnlockUserResponse
+	pattern := "/api/v1/users/{id}/unlock"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceUnlockUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateUser Users may update their own record; others need user:write (checked in UserUsecase)
 func (c *UserServiceHTTPClientImpl) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...http.CallOption) (*UpdateUserResponse, error) {
 	var out UpdateUserResponse
 	pattern := "/api/v1/users/{id}"
@@ -7,6 +7,7 @@
 package v1
 
 import (
+	_ "github.com/go-kratos/kratos-layout/api/authz"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -1055,7 +1056,7 @@ var File_ward_v1_ward_proto protoreflect.FileDescriptor
 
 const file_ward_v1_ward_proto_rawDesc = "" +
 	"\n" +
-	"\x12ward/v1/ward.proto\x12\award.v1\x1a\x1cgoogle/api/annotations.proto\"\xb9\x02\n" +
+	"\x12ward/v1/ward.proto\x12\award.v1\x1a\x11authz/authz.proto\x1a\x1cgoogle/api/annotations.proto\"\xb9\x02\n" +
 	"\x11CreateWardRequest\x12\x1f\n" +
 	"\vprovince_id\x18\x01 \x01(\tR\n" +
 	"provinceId\x12\x12\n" +
@@ -1128,7 +1129,7 @@ const file_ward_v1_ward_proto_rawDesc = "" +
 	"\vprovince_id\x18\x01 \x01(\tR\n" +
 	"provinceId\"B\n" +
 	"\x1bListWardsByProvinceResponse\x12#\n" +
-	"\x05wards\x18\x01 \x03(\v2\r.ward.v1.WardR\x05wards\"\xd0\x03\n" +
+	"\x05wards\x18\x01 \x03(\v2\r.ward.v1.WardR\x05wards\"\x84\x04\n" +
 	"\x04Ward\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vprovince_id\x18\x02 \x01(\tR\n" +
@@ -1152,22 +1153,30 @@ const file_ward_v1_ward_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\tR\tupdatedAt\x12\x1d\n" +
+	"updated_at\x18\x0f \x01(\tR\tupdatedAt\x12/\n" +
 	"\n" +
-	"created_by\x18\x10 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"created_by\x18\x10 \x01(\tB\x10\xda\xf3\x18\f\n" +
 	"\n" +
-	"updated_by\x18\x11 \x01(\tR\tupdatedBy2\xf1\x05\n" +
-	"\vWardService\x12_\n" +
+	"audit:readR\tcreatedBy\x12/\n" +
 	"\n" +
-	"CreateWard\x12\x1a.ward.v1.CreateWardRequest\x1a\x1b.ward.v1.CreateWardResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/wards\x12d\n" +
+	"updated_by\x18\x11 \x01(\tB\x10\xda\xf3\x18\f\n" +
 	"\n" +
-	"UpdateWard\x12\x1a.ward.v1.UpdateWardRequest\x1a\x1b.ward.v1.UpdateWardResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/api/v1/wards/{id}\x12a\n" +
+	"audit:readR\tupdatedBy:\x0e\xd2\xf3\x18\n" +
+	"created_by2\xde\x06\n" +
+	"\vWardService\x12o\n" +
 	"\n" +
-	"DeleteWard\x12\x1a.ward.v1.DeleteWardRequest\x1a\x1b.ward.v1.DeleteWardResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/wards/{id}\x12X\n" +
-	"\aGetWard\x12\x17.ward.v1.GetWardRequest\x1a\x18.ward.v1.GetWardResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/wards/{id}\x12q\n" +
-	"\rGetWardByCode\x12\x1d.ward.v1.GetWardByCodeRequest\x1a\x1e.ward.v1.GetWardByCodeResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/wards/code/{code}\x12Y\n" +
-	"\tListWards\x12\x19.ward.v1.ListWardsRequest\x1a\x1a.ward.v1.ListWardsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/wards\x12\x8f\x01\n" +
-	"\x13ListWardsByProvince\x12#.ward.v1.ListWardsByProvinceRequest\x1a$.ward.v1.ListWardsByProvinceResponse\"-\x82\xd3\xe4\x93\x02'\x12%/api/v1/provinces/{province_id}/wardsB3Z1github.com/go-kratos/kratos-layout/api/ward/v1;v1b\x06proto3"
+	"CreateWard\x12\x1a.ward.v1.CreateWardRequest\x1a\x1b.ward.v1.CreateWardResponse\"(\xca\xf3\x18\f\x1a\n" +
+	"ward:write\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/wards\x12t\n" +
+	"\n" +
+	"UpdateWard\x12\x1a.ward.v1.UpdateWardRequest\x1a\x1b.ward.v1.UpdateWardResponse\"-\xca\xf3\x18\f\x1a\n" +
+	"ward:write\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/api/v1/wards/{id}\x12q\n" +
+	"\n" +
+	"DeleteWard\x12\x1a.ward.v1.DeleteWardRequest\x1a\x1b.ward.v1.DeleteWardResponse\"*\xca\xf3\x18\f\x1a\n" +
+	"ward:write\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/wards/{id}\x12g\n" +
+	"\aGetWard\x12\x17.ward.v1.GetWardRequest\x1a\x18.ward.v1.GetWardResponse\")\xca\xf3\x18\v\x1a\tward:read\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/wards/{id}\x12\x80\x01\n" +
+	"\rGetWardByCode\x12\x1d.ward.v1.GetWardByCodeRequest\x1a\x1e.ward.v1.GetWardByCodeResponse\"0\xca\xf3\x18\v\x1a\tward:read\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/wards/code/{code}\x12h\n" +
+	"\tListWards\x12\x19.ward.v1.ListWardsRequest\x1a\x1a.ward.v1.ListWardsResponse\"$\xca\xf3\x18\v\x1a\tward:read\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/wards\x12\x9e\x01\n" +
+	"\x13ListWardsByProvince\x12#.ward.v1.ListWardsByProvinceRequest\x1a$.ward.v1.ListWardsByProvinceResponse\"<\xca\xf3\x18\v\x1a\tward:read\x82\xd3\xe4\x93\x02'\x12%/api/v1/provinces/{province_id}/wardsB3Z1github.com/go-kratos/kratos-layout/api/ward/v1;v1b\x06proto3"
 
 var (
 	file_ward_v1_ward_proto_rawDescOnce sync.Once
@@ -0,0 +1,44 @@
+// Command stub-idp runs a local OpenID Connect provider for trying out and
+// testing OIDC login without a real identity provider.
+//
+//	go run ./cmd/stub-idp -addr 127.0.0.1:9999 -email staff@example.com -groups admins
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/oidc/oidctest"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9999", "listen address")
+	clientID := flag.String("client-id", "backend-service", "OAuth client id")
+	clientSecret := flag.String("client-secret", "stub-secret", "OAuth client secret")
+	subject := flag.String("sub", "stub-user", "subject of the signed-in user")
+	email := flag.String("email", "stub.user@example.com", "email of the signed-in user")
+	name := flag.String("name", "Stub User", "name of the signed-in user")
+	groups := flag.String("groups", "", "comma separated groups claim")
+	flag.Parse()
+
+	idp, err := oidctest.NewIdP("http://"+*addr, *clientID, *clientSecret)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	claims := map[string]interface{}{
+		"sub":            *subject,
+		"email":          *email,
+		"email_verified": true,
+		"name":           *name,
+	}
+	if *groups != "" {
+		claims["groups"] = strings.Split(*groups, ",")
+	}
+	idp.SetUser(claims)
+
+	log.Printf("stub OpenID Connect provider listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, idp))
+}
@@ -0,0 +1,245 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrAPIKeyInvalid  = errors.Unauthorized("API_KEY_INVALID", "invalid, expired or revoked API key")
+	ErrAPIKeyNotFound = errors.NotFound("API_KEY_NOT_FOUND", "API key not found")
+)
+
+// apiKeyPrefixLength is the number of leading characters of a key stored in
+// clear text to identify it (e.g. "bk_3fZq8hT1")
+const apiKeyPrefixLength = 8
+
+// apiKeyTouchInterval limits how often last_used_at is written for a key
+const apiKeyTouchInterval = time.Minute
+
+// APIKey is a long-lived credential of a machine client acting as its owner.
+// Scopes list the operations the key may call ("/country.v1.CountryService/" or
+// "/user.v1.UserService/ListUsers"); AuthService operations are never allowed.
+type APIKey struct {
+	BaseEntity
+
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"` // Owner, the key acts as this user
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix     string     `gorm:"type:varchar(32);not null;index" json:"prefix"`    // Clear-text start of the key
+	KeyHash    string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`   // HMAC-SHA256 of the key
+	Scopes     string     `gorm:"type:text;not null" json:"scopes"`                 // Space separated
+	ExpiresAt  *time.Time `gorm:"type:timestamp;index" json:"expires_at,omitempty"` // nil = never expires
+	LastUsedAt *time.Time `gorm:"type:timestamp" json:"last_used_at,omitempty"`
+	Revoked    bool       `gorm:"default:false;index" json:"revoked"`
+	RevokedAt  *time.Time `gorm:"type:timestamp" json:"revoked_at,omitempty"`
+}
+
+// ScopeList returns the scopes of the key
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+// IsExpired checks if the key has expired
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+// APIKeyConfig configures API keys
+type APIKeyConfig struct {
+	Prefix     string        // Start of every key, identifies our keys in secret scanners
+	DefaultTTL time.Duration // Used when the request has no expiry, 0 = never expires
+	MaxTTL     time.Duration // 0 = unlimited
+}
+
+// CreateAPIKeyRequest creates a key for the current user
+type CreateAPIKeyRequest struct {
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration // 0 = DefaultTTL
+}
+
+// CreateAPIKeyResponse carries the key; it is only shown once
+type CreateAPIKeyResponse struct {
+	APIKey *APIKey
+	Key    string
+}
+
+// APIKeyCommandRepo for write operations
+type APIKeyCommandRepo interface {
+	SaveAPIKey(context.Context, *APIKey) (*APIKey, error)
+	// RevokeAPIKey revokes a key of a user; returns false if the user has no such active key
+	RevokeAPIKey(context.Context, uuid.UUID, uuid.UUID) (bool, error)
+	TouchAPIKey(context.Context, uuid.UUID, time.Time) error
+}
+
+// APIKeyQueryRepo for read operations
+type APIKeyQueryRepo interface {
+	FindAPIKeyByHash(context.Context, string) (*APIKey, error)
+	// ListUserAPIKeys returns the keys of a user that are not revoked, newest first
+	ListUserAPIKeys(context.Context, uuid.UUID) ([]*APIKey, error)
+}
+
+// CreateAPIKey creates a new API key for the current user
+func (uc *AuthUsecase) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrTokenInvalid
+	}
+	// A key would outlive the impersonation
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.BadRequest("INVALID_API_KEY", "name is required")
+	}
+	if err := validateAPIKeyScopes(req.Scopes); err != nil {
+		return nil, err
+	}
+
+	ttl := req.ExpiresIn
+	if ttl == 0 {
+		ttl = uc.config.APIKeys.DefaultTTL
+	}
+	if ttl < 0 || (uc.config.APIKeys.MaxTTL > 0 && (ttl == 0 || ttl > uc.config.APIKeys.MaxTTL)) {
+		return nil, errors.BadRequest("INVALID_API_KEY", "expiry exceeds the maximum lifetime of API keys")
+	}
+
+	key, err := uc.generateAPIKey()
+	if err != nil {
+		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate API key")
+	}
+
+	apiKey := &APIKey{
+		UserID:  userID,
+		Name:    strings.TrimSpace(req.Name),
+		Prefix:  key[:len(uc.config.APIKeys.Prefix)+1+apiKeyPrefixLength],
+		KeyHash: uc.hashToken(key),
+		Scopes:  strings.Join(req.Scopes, " "),
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		apiKey.ExpiresAt = &expiresAt
+	}
+	apiKey.SetAuditFields(ctx, true)
+
+	savedKey, err := uc.apiKeyCommandRepo.SaveAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, errors.InternalServer("TOKEN_SAVE_ERROR", "failed to save API key")
+	}
+
+	uc.log.WithContext(ctx).Infof("API key %s (%s) created for user: %s", savedKey.ID.String(), savedKey.Prefix, userID.String())
+	return &CreateAPIKeyResponse{APIKey: savedKey, Key: key}, nil
+}
+
+// ListAPIKeys lists the API keys of the current user
+func (uc *AuthUsecase) ListAPIKeys(ctx context.Context) ([]*APIKey, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrTokenInvalid
+	}
+	return uc.apiKeyQueryRepo.ListUserAPIKeys(ctx, userID)
+}
+
+// RevokeAPIKey revokes an API key of the current user
+func (uc *AuthUsecase) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return ErrTokenInvalid
+	}
+
+	revoked, err := uc.apiKeyCommandRepo.RevokeAPIKey(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	uc.log.WithContext(ctx).Infof("API key %s revoked by user: %s", id.String(), userID.String())
+	return nil
+}
+
+// AuthenticateAPIKey resolves the identity of an API key.
+// Used by AuthMiddleware for X-API-Key / "Authorization: ApiKey" requests.
+func (uc *AuthUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*middleware.APIKeyIdentity, error) {
+	// Keys are unique across tenants, the key names its tenant
+	apiKey, err := uc.apiKeyQueryRepo.FindAPIKeyByHash(tenant.WithAllTenants(ctx), uc.hashToken(key))
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || apiKey.Revoked || apiKey.IsExpired() {
+		return nil, ErrAPIKeyInvalid
+	}
+	ctx = inRecordTenant(ctx, &apiKey.BaseEntity)
+
+	// The key never has more rights than its owner has now
+	user, err := uc.userQueryRepo.FindByID(ctx, apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrAPIKeyInvalid
+		}
+		return nil, err
+	}
+	if !user.IsActive() || user.IsLocked() || uc.checkEmailVerified(user) != nil {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := uc.apiKeyCommandRepo.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			uc.log.WithContext(ctx).Errorf("Failed to update last use of API key %s: %v", apiKey.ID.String(), err)
+		}
+	}
+
+	grants, err := uc.tokenGrants(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &middleware.APIKeyIdentity{
+		KeyID:       apiKey.ID,
+		UserID:      user.ID,
+		TenantID:    grants.TenantID,
+		Email:       user.Email,
+		Role:        grants.Role,
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+		Scopes:      apiKey.ScopeList(),
+	}, nil
+}
+
+// generateAPIKey returns a new key: the configured prefix, "_" and 256 random bits
+func (uc *AuthUsecase) generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return uc.config.APIKeys.Prefix + "_" + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// validateAPIKeyScopes checks that scopes are operation names or service prefixes.
+// Keys cannot manage credentials, so AuthService is never in scope.
+func validateAPIKeyScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return errors.BadRequest("INVALID_API_KEY", "at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !strings.HasPrefix(scope, "/") || strings.ContainsAny(scope, " \t\n") {
+			return errors.BadRequest("INVALID_API_KEY", "invalid scope: "+scope)
+		}
+		if strings.HasPrefix("/auth.v1.AuthService/", scope) || strings.HasPrefix(scope, "/auth.v1.AuthService/") {
+			return errors.BadRequest("INVALID_API_KEY", "API keys cannot access AuthService")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Audit actions
+const (
+	AuditActionAdminPasswordReset = "user.password_reset_by_admin"
+	AuditActionImpersonate        = "user.impersonate"
+	AuditActionRoleCreate         = "role.create"
+	AuditActionRoleUpdate         = "role.update"
+	AuditActionRoleDelete         = "role.delete"
+	AuditActionRoleAssign         = "user.role_assign"
+	AuditActionRoleRemove         = "user.role_remove"
+)
+
+// AuditLog records a security-relevant action taken by one user on another
+type AuditLog struct {
+	BaseEntity
+
+	ActorID    *uuid.UUID `gorm:"type:uuid;index" json:"actor_id,omitempty"` // Who did it (nil = system)
+	Action     string     `gorm:"type:varchar(100);not null;index" json:"action"`
+	TargetType string     `gorm:"type:varchar(50);not null" json:"target_type"` // e.g. "user"
+	TargetID   *uuid.UUID `gorm:"type:uuid;index" json:"target_id,omitempty"`
+	IPAddress  string     `gorm:"type:varchar(45)" json:"ip_address"`
+	Details    string     `gorm:"type:text" json:"details,omitempty"`
+}
+
+// AuditLogCommandRepo for write operations
+type AuditLogCommandRepo interface {
+	SaveAuditLog(context.Context, *AuditLog) (*AuditLog, error)
+}
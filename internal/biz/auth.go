@@ -5,8 +5,11 @@ import (
 	"time"
 
 	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/middleware"
 	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
 	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tokenhash"
 	"github.com/gofrs/uuid/v5"
 
 	"github.com/go-kratos/kratos/v2/errors"
@@ -18,28 +21,35 @@ var (
 	ErrTokenExpired       = errors.Unauthorized("TOKEN_EXPIRED", "token has expired")
 	ErrTokenInvalid       = errors.Unauthorized("TOKEN_INVALID", "invalid token")
 	ErrTokenRevoked       = errors.Unauthorized("TOKEN_REVOKED", "token has been revoked")
+	ErrTokenReused        = errors.Unauthorized("TOKEN_REUSED", "refresh token has already been used")
 )
 
 // AuthToken represents authentication token stored in database
 type AuthToken struct {
 	BaseEntity
 
-	UserID            uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	Token             string    `gorm:"type:text;not null" json:"token"` // Access token (for reference)
-	RefreshToken      string    `gorm:"type:text;not null;uniqueIndex" json:"refresh_token"`
-	ExpiresAt         time.Time `gorm:"not null;index" json:"expires_at"`
-	RefreshExpiresAt  time.Time `gorm:"not null;index" json:"refresh_expires_at"`
-	IPAddress         string    `gorm:"type:varchar(45)" json:"ip_address"`
-	UserAgent         string    `gorm:"type:text" json:"user_agent"`
-	Revoked           bool      `gorm:"default:false;index" json:"revoked"`
-	RevokedAt         *time.Time `gorm:"type:timestamp" json:"revoked_at,omitempty"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash        string     `gorm:"type:varchar(64);not null;index" json:"-"`       // HMAC-SHA256 of access token
+	RefreshTokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // HMAC-SHA256 of refresh token
+	ExpiresAt        time.Time  `gorm:"not null;index" json:"expires_at"`
+	RefreshExpiresAt time.Time  `gorm:"not null;index" json:"refresh_expires_at"`
+	IPAddress        string     `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent        string     `gorm:"type:text" json:"user_agent"`
+	Revoked          bool       `gorm:"default:false;index" json:"revoked"`
+	RevokedAt        *time.Time `gorm:"type:timestamp" json:"revoked_at,omitempty"`
+	LastUsedAt       *time.Time `gorm:"type:timestamp" json:"last_used_at,omitempty"` // Last login/refresh with this token
+
+	// Refresh token rotation: every token issued from the same login shares a
+	// FamilyID, and a rotated token points to the token that replaced it.
+	FamilyID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	ReplacedByID *uuid.UUID `gorm:"type:uuid" json:"replaced_by_id,omitempty"`
 }
 
 // LoginRequest for authentication
 type LoginRequest struct {
-	Email    string // Email or username
-	Password string
-	IP       string
+	Email     string // Email or username
+	Password  string
+	IP        string
 	UserAgent string
 }
 
@@ -50,70 +60,180 @@ type LoginResponse struct {
 	ExpiresIn    int64
 	TokenType    string // "Bearer"
 	User         *User
+
+	// MFA: when MFARequired is set no tokens are issued, the client must call
+	// VerifyMFA with MFAToken
+	MFARequired   bool
+	MFAToken      string
+	MFAEnrollment *MFAEnrollment // Set when enrollment is mandatory but not done yet
+	RecoveryCodes []string       // Set when VerifyMFA completed a mandatory enrollment
+
+	// Password expiry: when PasswordChangeRequired is set no tokens are issued,
+	// PasswordChangeToken is only accepted by ChangeMyPassword
+	PasswordChangeRequired bool
+	PasswordChangeToken    string
 }
 
 // RegisterRequest for user registration
 type RegisterRequest struct {
-	Email    string
-	Username string
-	Password string
-	FullName string
-	IP       string
+	Email     string
+	Username  string
+	Password  string
+	FullName  string
+	IP        string
 	UserAgent string
 }
 
 // AuthCommandRepo for write operations
 type AuthCommandRepo interface {
 	SaveToken(context.Context, *AuthToken) (*AuthToken, error)
-	RevokeToken(context.Context, string) error // Revoke by refresh token
+	RevokeToken(context.Context, string) error // Revoke by refresh token hash
 	RevokeAllUserTokens(context.Context, uuid.UUID) error
+	// RotateToken atomically revokes the old token (by ID) and saves its replacement.
+	// Returns ErrTokenReused if the old token was already revoked or rotated.
+	RotateToken(context.Context, uuid.UUID, *AuthToken) (*AuthToken, error)
+	RevokeTokenFamily(context.Context, uuid.UUID) error
 }
 
-// AuthQueryRepo for read operations
+// AuthQueryRepo for read operations.
+// Tokens are looked up by their keyed hash, never by the raw token.
 type AuthQueryRepo interface {
-	FindTokenByRefreshToken(context.Context, string) (*AuthToken, error)
-	FindTokenByAccessToken(context.Context, string) (*AuthToken, error)
+	FindTokenByRefreshTokenHash(context.Context, string) (*AuthToken, error)
+	FindTokenByAccessTokenHash(context.Context, string) (*AuthToken, error)
 	ListUserTokens(context.Context, uuid.UUID) ([]*AuthToken, error)
+	// ListUserSessions returns the active sessions (token families) of a user, newest first
+	ListUserSessions(context.Context, uuid.UUID) ([]*Session, error)
+	// IsSessionActive reports whether a session (token family) still has an unrevoked, unexpired token
+	IsSessionActive(context.Context, uuid.UUID) (bool, error)
+}
+
+// SessionRevocationRepo tracks revoked login sessions so access tokens can be
+// rejected before they expire
+type SessionRevocationRepo interface {
+	IsSessionRevoked(context.Context, uuid.UUID) (bool, error)
+	// MarkSessionRevoked records a revocation; ttl is how long access tokens of the session stay valid
+	MarkSessionRevoked(context.Context, uuid.UUID, time.Duration) error
 }
 
 // AuthUsecase handles authentication logic
 type AuthUsecase struct {
-	userQueryRepo   UserQueryRepo
-	userCommandRepo UserCommandRepo
-	authCommandRepo AuthCommandRepo
-	authQueryRepo   AuthQueryRepo
-	jwtSecret       []byte
-	accessExpiry    time.Duration
-	refreshExpiry   time.Duration
-	log             *log.Helper
+	userQueryRepo           UserQueryRepo
+	userCommandRepo         UserCommandRepo
+	authCommandRepo         AuthCommandRepo
+	authQueryRepo           AuthQueryRepo
+	revocationRepo          SessionRevocationRepo
+	mfaCommandRepo          MFACommandRepo
+	mfaQueryRepo            MFAQueryRepo
+	resetRepo               PasswordResetCommandRepo
+	verificationRepo        EmailVerificationCommandRepo
+	apiKeyCommandRepo       APIKeyCommandRepo
+	apiKeyQueryRepo         APIKeyQueryRepo
+	oidcCommandRepo         OIDCCommandRepo
+	oidcQueryRepo           OIDCQueryRepo
+	roleQueryRepo           RoleQueryRepo
+	tenantQueryRepo         TenantQueryRepo
+	auditRepo               AuditLogCommandRepo
+	loginHistoryCommandRepo LoginHistoryCommandRepo
+	loginHistoryQueryRepo   LoginHistoryQueryRepo
+	mailer                  Mailer
+	loginNotifier           LoginNotifier
+	breachedPasswords       BreachedPasswordChecker
+	keys                    *jwt.KeySet
+	config                  *AuthConfig // Per-feature settings (MFA, lockout, OIDC, ...)
+	tokenPepper             []byte
+	accessExpiry            time.Duration
+	refreshExpiry           time.Duration
+	oidcProviders           map[string]*oidcProvider
+	log                     *log.Helper
+}
+
+// AuthRepos groups the repositories used by AuthUsecase (provided by wire.Struct)
+type AuthRepos struct {
+	UserQuery           UserQueryRepo
+	UserCommand         UserCommandRepo
+	AuthCommand         AuthCommandRepo
+	AuthQuery           AuthQueryRepo
+	Revocation          SessionRevocationRepo
+	MFACommand          MFACommandRepo
+	MFAQuery            MFAQueryRepo
+	PasswordReset       PasswordResetCommandRepo
+	EmailVerification   EmailVerificationCommandRepo
+	APIKeyCommand       APIKeyCommandRepo
+	APIKeyQuery         APIKeyQueryRepo
+	OIDCCommand         OIDCCommandRepo
+	OIDCQuery           OIDCQueryRepo
+	RoleQuery           RoleQueryRepo
+	TenantQuery         TenantQueryRepo
+	Audit               AuditLogCommandRepo
+	LoginHistoryCommand LoginHistoryCommandRepo
+	LoginHistoryQuery   LoginHistoryQueryRepo
 }
 
 // NewAuthUsecase creates a new AuthUsecase
 func NewAuthUsecase(
-	userQueryRepo UserQueryRepo,
-	userCommandRepo UserCommandRepo,
-	authCommandRepo AuthCommandRepo,
-	authQueryRepo AuthQueryRepo,
+	repos AuthRepos,
+	mailer Mailer,
+	loginNotifier LoginNotifier,
+	breachedPasswords BreachedPasswordChecker,
 	authConfig *AuthConfig,
+	keys *jwt.KeySet,
 	logger log.Logger,
 ) *AuthUsecase {
 	return &AuthUsecase{
-		userQueryRepo:   userQueryRepo,
-		userCommandRepo: userCommandRepo,
-		authCommandRepo: authCommandRepo,
-		authQueryRepo:   authQueryRepo,
-		jwtSecret:       []byte(authConfig.JwtSecret),
-		accessExpiry:    time.Duration(authConfig.AccessExpiry) * time.Second,
-		refreshExpiry:   time.Duration(authConfig.RefreshExpiry) * time.Second,
-		log:             log.NewHelper(logger),
+		userQueryRepo:           repos.UserQuery,
+		userCommandRepo:         repos.UserCommand,
+		authCommandRepo:         repos.AuthCommand,
+		authQueryRepo:           repos.AuthQuery,
+		revocationRepo:          repos.Revocation,
+		mfaCommandRepo:          repos.MFACommand,
+		mfaQueryRepo:            repos.MFAQuery,
+		resetRepo:               repos.PasswordReset,
+		verificationRepo:        repos.EmailVerification,
+		apiKeyCommandRepo:       repos.APIKeyCommand,
+		apiKeyQueryRepo:         repos.APIKeyQuery,
+		oidcCommandRepo:         repos.OIDCCommand,
+		oidcQueryRepo:           repos.OIDCQuery,
+		roleQueryRepo:           repos.RoleQuery,
+		tenantQueryRepo:         repos.TenantQuery,
+		auditRepo:               repos.Audit,
+		loginHistoryCommandRepo: repos.LoginHistoryCommand,
+		loginHistoryQueryRepo:   repos.LoginHistoryQuery,
+		mailer:                  mailer,
+		loginNotifier:           loginNotifier,
+		breachedPasswords:       breachedPasswords,
+		keys:                    keys,
+		config:                  authConfig,
+		tokenPepper:             []byte(authConfig.TokenPepper),
+		accessExpiry:            time.Duration(authConfig.AccessExpiry) * time.Second,
+		refreshExpiry:           time.Duration(authConfig.RefreshExpiry) * time.Second,
+		oidcProviders:           newOIDCProviders(authConfig.OIDC),
+		log:                     log.NewHelper(logger),
 	}
 }
 
 // AuthConfig wraps auth configuration
 type AuthConfig struct {
-	JwtSecret      string
-	AccessExpiry   int64
-	RefreshExpiry  int64
+	JwtSecret         string
+	TokenPepper       string // Key for hashing stored tokens
+	AccessExpiry      int64
+	RefreshExpiry     int64
+	MFA               MFAConfig
+	PasswordReset     PasswordResetConfig
+	EmailVerification EmailVerificationConfig
+	Lockout           LockoutConfig
+	APIKeys           APIKeyConfig
+	OIDC              OIDCConfig
+	PasswordPolicy    PasswordPolicyConfig
+	Impersonation     ImpersonationConfig
+	Introspection     IntrospectionConfig
+}
+
+// MFAConfig configures TOTP two-factor authentication
+type MFAConfig struct {
+	Issuer           string
+	RequireForAdmins bool
+	ChallengeTTL     time.Duration
+	EncryptionKey    string
 }
 
 // NewAuthConfigFromConf creates AuthConfig from conf.Auth
@@ -121,16 +241,164 @@ func NewAuthConfigFromConf(auth *conf.Auth) *AuthConfig {
 	if auth == nil {
 		// Default values
 		return &AuthConfig{
-			JwtSecret:     "default-secret-key-change-in-production",
-			AccessExpiry:  3600,   // 1 hour
-			RefreshExpiry: 604800, // 7 days
+			JwtSecret:         "default-secret-key-change-in-production",
+			TokenPepper:       "default-secret-key-change-in-production",
+			AccessExpiry:      3600,   // 1 hour
+			RefreshExpiry:     604800, // 7 days
+			MFA:               newMFAConfigFromConf(nil, "default-secret-key-change-in-production"),
+			PasswordReset:     newPasswordResetConfigFromConf(nil),
+			EmailVerification: newEmailVerificationConfigFromConf(nil),
+			Lockout:           newLockoutConfigFromConf(nil),
+			APIKeys:           newAPIKeyConfigFromConf(nil),
+			OIDC:              newOIDCConfigFromConf(nil),
+			PasswordPolicy:    newPasswordPolicyConfigFromConf(nil),
+			Impersonation:     newImpersonationConfigFromConf(nil),
+			Introspection:     newIntrospectionConfigFromConf(nil),
 		}
 	}
+	tokenPepper := auth.TokenPepper
+	if tokenPepper == "" {
+		// Backward compatibility: fall back to the JWT secret
+		tokenPepper = auth.JwtSecret
+	}
 	return &AuthConfig{
-		JwtSecret:     auth.JwtSecret,
-		AccessExpiry:   auth.AccessTokenExpiry,
-		RefreshExpiry: auth.RefreshTokenExpiry,
+		JwtSecret:         auth.JwtSecret,
+		TokenPepper:       tokenPepper,
+		AccessExpiry:      auth.AccessTokenExpiry,
+		RefreshExpiry:     auth.RefreshTokenExpiry,
+		MFA:               newMFAConfigFromConf(auth.Mfa, tokenPepper),
+		PasswordReset:     newPasswordResetConfigFromConf(auth.PasswordReset),
+		EmailVerification: newEmailVerificationConfigFromConf(auth.EmailVerification),
+		Lockout:           newLockoutConfigFromConf(auth.Lockout),
+		APIKeys:           newAPIKeyConfigFromConf(auth.ApiKeys),
+		OIDC:              newOIDCConfigFromConf(auth.Oidc),
+		PasswordPolicy:    newPasswordPolicyConfigFromConf(auth.PasswordPolicy),
+		Impersonation:     newImpersonationConfigFromConf(auth.Impersonation),
+		Introspection:     newIntrospectionConfigFromConf(auth.Introspection),
+	}
+}
+
+func newAPIKeyConfigFromConf(c *conf.Auth_APIKeys) APIKeyConfig {
+	cfg := APIKeyConfig{
+		Prefix:     "bk",
+		DefaultTTL: 90 * 24 * time.Hour,
+		MaxTTL:     365 * 24 * time.Hour,
+	}
+	if c == nil {
+		return cfg
+	}
+	if c.Prefix != "" {
+		cfg.Prefix = c.Prefix
+	}
+	if c.DefaultTtl != nil && c.DefaultTtl.AsDuration() > 0 {
+		cfg.DefaultTTL = c.DefaultTtl.AsDuration()
+	}
+	if c.MaxTtl != nil {
+		// 0 allows keys that never expire
+		cfg.MaxTTL = c.MaxTtl.AsDuration()
+	}
+	return cfg
+}
+
+func newLockoutConfigFromConf(c *conf.Auth_Lockout) LockoutConfig {
+	cfg := LockoutConfig{
+		MaxAttempts:     5,
+		LockDuration:    15 * time.Minute,
+		MaxLockDuration: 24 * time.Hour,
+	}
+	if c == nil {
+		return cfg
+	}
+	if c.MaxAttempts > 0 {
+		cfg.MaxAttempts = int(c.MaxAttempts)
+	}
+	if c.LockDuration != nil && c.LockDuration.AsDuration() > 0 {
+		cfg.LockDuration = c.LockDuration.AsDuration()
 	}
+	if c.MaxLockDuration != nil && c.MaxLockDuration.AsDuration() > 0 {
+		cfg.MaxLockDuration = c.MaxLockDuration.AsDuration()
+	}
+	return cfg
+}
+
+func newPasswordResetConfigFromConf(c *conf.Auth_PasswordReset) PasswordResetConfig {
+	cfg := PasswordResetConfig{
+		TokenTTL: 30 * time.Minute,
+	}
+	if c == nil {
+		return cfg
+	}
+	if c.TokenTtl != nil && c.TokenTtl.AsDuration() > 0 {
+		cfg.TokenTTL = c.TokenTtl.AsDuration()
+	}
+	cfg.URL = c.Url
+	return cfg
+}
+
+func newEmailVerificationConfigFromConf(c *conf.Auth_EmailVerification) EmailVerificationConfig {
+	cfg := EmailVerificationConfig{
+		Mode:           EmailVerificationModeNone,
+		RestrictedRole: "unverified",
+		TokenTTL:       48 * time.Hour,
+	}
+	if c == nil {
+		return cfg
+	}
+	if c.Mode != "" {
+		cfg.Mode = c.Mode
+	}
+	if c.RestrictedRole != "" {
+		cfg.RestrictedRole = c.RestrictedRole
+	}
+	if c.TokenTtl != nil && c.TokenTtl.AsDuration() > 0 {
+		cfg.TokenTTL = c.TokenTtl.AsDuration()
+	}
+	cfg.URL = c.Url
+	return cfg
+}
+
+func newMFAConfigFromConf(mfa *conf.Auth_MFA, tokenPepper string) MFAConfig {
+	cfg := MFAConfig{
+		Issuer:        "Backend Service",
+		ChallengeTTL:  5 * time.Minute,
+		EncryptionKey: tokenPepper,
+	}
+	if mfa == nil {
+		return cfg
+	}
+	if mfa.Issuer != "" {
+		cfg.Issuer = mfa.Issuer
+	}
+	if mfa.ChallengeTtl != nil && mfa.ChallengeTtl.AsDuration() > 0 {
+		cfg.ChallengeTTL = mfa.ChallengeTtl.AsDuration()
+	}
+	if mfa.EncryptionKey != "" {
+		cfg.EncryptionKey = mfa.EncryptionKey
+	}
+	cfg.RequireForAdmins = mfa.RequireForAdmins
+	return cfg
+}
+
+// NewJWTKeySetFromConf loads the JWT signing/verification keys from conf.Auth.
+// Without signing_keys it falls back to HS256 with the shared jwt_secret.
+func NewJWTKeySetFromConf(auth *conf.Auth) (*jwt.KeySet, error) {
+	if auth == nil {
+		return jwt.NewHMACKeySet([]byte(NewAuthConfigFromConf(nil).JwtSecret)), nil
+	}
+	if len(auth.SigningKeys) == 0 {
+		return jwt.NewHMACKeySet([]byte(auth.JwtSecret)), nil
+	}
+
+	configs := make([]jwt.KeyConfig, 0, len(auth.SigningKeys))
+	for _, k := range auth.SigningKeys {
+		configs = append(configs, jwt.KeyConfig{
+			ID:             k.Kid,
+			Algorithm:      k.Algorithm,
+			PrivateKeyFile: k.PrivateKeyFile,
+			PublicKeyFile:  k.PublicKeyFile,
+		})
+	}
+	return jwt.LoadKeySet(configs, auth.ActiveKeyId)
 }
 
 // Login authenticates user and returns tokens
@@ -161,21 +429,78 @@ func (uc *AuthUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginResp
 
 	// If still not found after trying both, return invalid credentials
 	if user == nil {
+		uc.recordLoginFailure(ctx, nil, req.Email, LoginMethodPassword, LoginFailureUnknownUser, req.IP, req.UserAgent)
 		return nil, ErrInvalidCredentials
 	}
 
+	// Locked accounts are rejected before the password is checked
+	if err := uc.checkAccountLock(user); err != nil {
+		uc.recordLoginFailure(ctx, user, req.Email, LoginMethodPassword, LoginFailureAccountLocked, req.IP, req.UserAgent)
+		return nil, err
+	}
+
 	// Verify password
-	if !password.Verify(req.Password, user.PasswordHash) {
+	ok, needsRehash := password.Verify(req.Password, user.PasswordHash)
+	if !ok {
+		uc.recordFailedLogin(ctx, user)
+		uc.recordLoginFailure(ctx, user, req.Email, LoginMethodPassword, LoginFailureInvalidPassword, req.IP, req.UserAgent)
 		return nil, ErrInvalidCredentials
 	}
 
+	// Upgrade hashes made with an old algorithm or cost while the password is known
+	if needsRehash {
+		uc.rehashPassword(ctx, user, req.Password)
+	}
+
 	// Check if user is active
 	if !user.IsActive() {
+		uc.recordLoginFailure(ctx, user, req.Email, LoginMethodPassword, LoginFailureUserInactive, req.IP, req.UserAgent)
 		return nil, errors.Forbidden("USER_INACTIVE", "user account is inactive")
 	}
 
+	if err := uc.checkEmailVerified(user); err != nil {
+		uc.recordLoginFailure(ctx, user, req.Email, LoginMethodPassword, LoginFailureEmailNotVerified, req.IP, req.UserAgent)
+		return nil, err
+	}
+
+	// Expired passwords must be changed before any session is issued
+	expired, err := uc.passwordExpired(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		uc.resetFailedLogins(ctx, user)
+		return uc.passwordChangeChallenge(ctx, user)
+	}
+
+	// Enrolled users (and admins when MFA is mandatory) get an MFA challenge instead of tokens.
+	// The failed-login counter is kept until the second factor is verified, so wrong codes
+	// count together with the wrong passwords before them.
+	challenge, err := uc.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		uc.log.WithContext(ctx).Infof("MFA challenge issued: %s", user.Email)
+		return challenge, nil
+	}
+	uc.resetFailedLogins(ctx, user)
+
+	return uc.issueSession(ctx, user, LoginMethodPassword, req.IP, req.UserAgent)
+}
+
+// issueSession starts a new login session for an authenticated user and returns its tokens.
+// method (password, mfa, oidc) is kept in the login history.
+func (uc *AuthUsecase) issueSession(ctx context.Context, user *User, method, ip, userAgent string) (*LoginResponse, error) {
+	// New login starts a new session (token family)
+	sessionID := uuid.Must(uuid.NewV7())
+
 	// Generate tokens
-	accessToken, err := jwt.GenerateAccessToken(user.ID, user.Email, user.Role, uc.jwtSecret, uc.accessExpiry)
+	grants, err := uc.tokenGrants(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := jwt.GenerateAccessToken(user.ID, user.Email, grants, sessionID, uc.keys, uc.accessExpiry)
 	if err != nil {
 		uc.log.WithContext(ctx).Errorf("Failed to generate access token: %v", err)
 		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate token")
@@ -191,14 +516,16 @@ func (uc *AuthUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginResp
 	now := time.Now()
 	authToken := &AuthToken{
 		UserID:           user.ID,
-		Token:            accessToken, // Store for reference
-		RefreshToken:     refreshToken,
+		TokenHash:        uc.hashToken(accessToken),
+		RefreshTokenHash: uc.hashToken(refreshToken),
 		ExpiresAt:        now.Add(uc.accessExpiry),
 		RefreshExpiresAt: now.Add(uc.refreshExpiry),
-		IPAddress:        req.IP,
-		UserAgent:        req.UserAgent,
+		IPAddress:        ip,
+		UserAgent:        userAgent,
+		FamilyID:         sessionID,
+		LastUsedAt:       &now,
 	}
-	
+
 	// Set audit fields - user is creating their own token
 	authToken.SetAuditFields(ctx, true)
 	// If no user in context (public login), set created_by to the user themselves
@@ -207,23 +534,25 @@ func (uc *AuthUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginResp
 		authToken.UpdatedBy = &user.ID
 	}
 
-	savedToken, err := uc.authCommandRepo.SaveToken(ctx, authToken)
+	_, err = uc.authCommandRepo.SaveToken(ctx, authToken)
 	if err != nil {
 		uc.log.WithContext(ctx).Errorf("Failed to save token: %v", err)
 		return nil, errors.InternalServer("TOKEN_SAVE_ERROR", "failed to save token")
 	}
 
 	// Update last login
-	if err := uc.userCommandRepo.UpdateLastLogin(ctx, user.ID, req.IP); err != nil {
+	if err := uc.userCommandRepo.UpdateLastLogin(ctx, user.ID, ip); err != nil {
 		uc.log.WithContext(ctx).Warnf("Failed to update last login: %v", err)
 		// Don't fail login if this fails
 	}
 
+	uc.recordLoginSuccess(ctx, user, method, ip, userAgent, sessionID)
+
 	uc.log.WithContext(ctx).Infof("User logged in successfully: %s", user.Email)
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
-		RefreshToken: savedToken.RefreshToken,
+		RefreshToken: refreshToken,
 		ExpiresIn:    int64(uc.accessExpiry.Seconds()),
 		TokenType:    "Bearer",
 		User:         user,
@@ -234,6 +563,10 @@ func (uc *AuthUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginResp
 func (uc *AuthUsecase) Register(ctx context.Context, req *RegisterRequest) (*LoginResponse, error) {
 	uc.log.WithContext(ctx).Infof("Register attempt: %s", req.Email)
 
+	if err := checkPasswordBreached(uc.breachedPasswords, req.Password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	passwordHash, err := password.Hash(req.Password)
 	if err != nil {
@@ -241,14 +574,16 @@ func (uc *AuthUsecase) Register(ctx context.Context, req *RegisterRequest) (*Log
 	}
 
 	// Create user
+	passwordChangedAt := time.Now()
 	user := &User{
-		Email:        req.Email,
-		Username:     req.Username,
-		PasswordHash: passwordHash,
-		FullName:     req.FullName,
-		Role:         "user", // Default role
+		Email:             req.Email,
+		Username:          req.Username,
+		PasswordHash:      passwordHash,
+		PasswordChangedAt: &passwordChangedAt,
+		FullName:          req.FullName,
+		Role:              RoleUser, // Default role
 	}
-	
+
 	// Try to set audit fields from context (if authenticated user is creating)
 	user.SetAuditFields(ctx, true)
 
@@ -256,7 +591,7 @@ func (uc *AuthUsecase) Register(ctx context.Context, req *RegisterRequest) (*Log
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// If no created_by was set (public register), set it to the user themselves
 	if createdUser.CreatedBy == nil {
 		createdUser.CreatedBy = &createdUser.ID
@@ -269,59 +604,28 @@ func (uc *AuthUsecase) Register(ctx context.Context, req *RegisterRequest) (*Log
 		}
 	}
 
-	// Generate tokens
-	accessToken, err := jwt.GenerateAccessToken(createdUser.ID, createdUser.Email, createdUser.Role, uc.jwtSecret, uc.accessExpiry)
-	if err != nil {
-		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate token")
-	}
+	uc.sendVerificationEmail(ctx, createdUser)
 
-	refreshToken, err := jwt.GenerateRefreshToken()
-	if err != nil {
-		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate refresh token")
-	}
-
-	// Save refresh token
-	now := time.Now()
-	authToken := &AuthToken{
-		UserID:           createdUser.ID,
-		Token:            accessToken,
-		RefreshToken:     refreshToken,
-		ExpiresAt:        now.Add(uc.accessExpiry),
-		RefreshExpiresAt: now.Add(uc.refreshExpiry),
-		IPAddress:        req.IP,
-		UserAgent:        req.UserAgent,
-	}
-	
-	// Set audit fields - user is creating their own token
-	authToken.SetAuditFields(ctx, true)
-	// If no user in context (public register), set created_by to the user themselves
-	if authToken.CreatedBy == nil {
-		authToken.CreatedBy = &createdUser.ID
-		authToken.UpdatedBy = &createdUser.ID
-	}
-
-	savedToken, err := uc.authCommandRepo.SaveToken(ctx, authToken)
-	if err != nil {
-		return nil, errors.InternalServer("TOKEN_SAVE_ERROR", "failed to save token")
+	// No tokens until the email is verified
+	if err := uc.checkEmailVerified(createdUser); err != nil {
+		uc.log.WithContext(ctx).Infof("User registered, waiting for email verification: %s", createdUser.Email)
+		return &LoginResponse{User: createdUser}, nil
 	}
 
 	uc.log.WithContext(ctx).Infof("User registered successfully: %s", createdUser.Email)
 
-	return &LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: savedToken.RefreshToken,
-		ExpiresIn:    int64(uc.accessExpiry.Seconds()),
-		TokenType:    "Bearer",
-		User:         createdUser,
-	}, nil
+	// Registration starts the first session: last login and login history as for Login
+	return uc.issueSession(ctx, createdUser, LoginMethodPassword, req.IP, req.UserAgent)
 }
 
-// RefreshToken generates new access token from refresh token
+// RefreshToken rotates the refresh token: the presented token is revoked and
+// a new access/refresh token pair from the same token family is returned.
+// Presenting a token that was already rotated revokes the whole family.
 func (uc *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string) (*LoginResponse, error) {
 	uc.log.WithContext(ctx).Info("Refresh token request")
 
-	// Find token in database
-	token, err := uc.authQueryRepo.FindTokenByRefreshToken(ctx, refreshToken)
+	// Find token in database; refresh tokens are unique across tenants and name their tenant
+	token, err := uc.authQueryRepo.FindTokenByRefreshTokenHash(tenant.WithAllTenants(ctx), uc.hashToken(refreshToken))
 	if err != nil {
 		return nil, err
 	}
@@ -329,9 +633,15 @@ func (uc *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string) (*
 	if token == nil {
 		return nil, ErrTokenInvalid
 	}
+	ctx = inRecordTenant(ctx, &token.BaseEntity)
 
 	// Check if token is revoked
 	if token.Revoked {
+		// A rotated token is only ever presented again if it was stolen
+		if token.ReplacedByID != nil {
+			uc.revokeReusedTokenFamily(ctx, token)
+			return nil, ErrTokenReused
+		}
 		return nil, ErrTokenRevoked
 	}
 
@@ -355,44 +665,114 @@ func (uc *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string) (*
 		return nil, errors.Forbidden("USER_INACTIVE", "user account is inactive")
 	}
 
-	// Generate new access token
-	accessToken, err := jwt.GenerateAccessToken(user.ID, user.Email, user.Role, uc.jwtSecret, uc.accessExpiry)
+	if err := uc.checkEmailVerified(user); err != nil {
+		return nil, err
+	}
+
+	// Generate new access token; roles and permissions are reloaded so changes apply on refresh
+	grants, err := uc.tokenGrants(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := jwt.GenerateAccessToken(user.ID, user.Email, grants, token.FamilyID, uc.keys, uc.accessExpiry)
 	if err != nil {
 		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate token")
 	}
 
-	// Update token in database
-	token.Token = accessToken
-	token.ExpiresAt = time.Now().Add(uc.accessExpiry)
-	
+	newRefreshToken, err := jwt.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate refresh token")
+	}
+
+	now := time.Now()
+	newToken := &AuthToken{
+		UserID:           token.UserID,
+		TokenHash:        uc.hashToken(accessToken),
+		RefreshTokenHash: uc.hashToken(newRefreshToken),
+		ExpiresAt:        now.Add(uc.accessExpiry),
+		RefreshExpiresAt: now.Add(uc.refreshExpiry),
+		IPAddress:        token.IPAddress,
+		UserAgent:        token.UserAgent,
+		FamilyID:         token.FamilyID,
+		LastUsedAt:       &now,
+	}
+
 	// Set audit fields from context
-	token.SetAuditFields(ctx, false)
-	// If no user in context, set updated_by to the token's user
-	if token.UpdatedBy == nil {
-		token.UpdatedBy = &token.UserID
+	newToken.SetAuditFields(ctx, true)
+	// If no user in context, set created_by to the token's user
+	if newToken.CreatedBy == nil {
+		newToken.CreatedBy = &token.UserID
+		newToken.UpdatedBy = &token.UserID
 	}
-	
-	_, err = uc.authCommandRepo.SaveToken(ctx, token)
+
+	_, err = uc.authCommandRepo.RotateToken(ctx, token.ID, newToken)
 	if err != nil {
-		return nil, errors.InternalServer("TOKEN_SAVE_ERROR", "failed to update token")
+		// Another request rotated this token first: treat it as reuse
+		if errors.Is(err, ErrTokenReused) {
+			uc.revokeReusedTokenFamily(ctx, token)
+			return nil, ErrTokenReused
+		}
+		return nil, errors.InternalServer("TOKEN_SAVE_ERROR", "failed to rotate token")
 	}
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
-		RefreshToken: token.RefreshToken, // Keep same refresh token
+		RefreshToken: newRefreshToken,
 		ExpiresIn:    int64(uc.accessExpiry.Seconds()),
 		TokenType:    "Bearer",
 		User:         user,
 	}, nil
 }
 
-// Logout revokes token
+// revokeReusedTokenFamily logs a security event and revokes every token of the
+// family the reused token belongs to
+func (uc *AuthUsecase) revokeReusedTokenFamily(ctx context.Context, token *AuthToken) {
+	uc.log.WithContext(ctx).Warnf(
+		"Security event: refresh token reuse detected (user=%s family=%s token=%s ip=%s); revoking token family",
+		token.UserID.String(), token.FamilyID.String(), token.ID.String(), token.IPAddress,
+	)
+	if err := uc.authCommandRepo.RevokeTokenFamily(ctx, token.FamilyID); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to revoke token family %s: %v", token.FamilyID.String(), err)
+		return
+	}
+	uc.markSessionRevoked(ctx, token.FamilyID)
+}
+
+// Logout revokes the session of the given refresh token, or the session of the
+// current access token when no refresh token is given
 func (uc *AuthUsecase) Logout(ctx context.Context, refreshToken string) error {
 	uc.log.WithContext(ctx).Info("Logout request")
 
-	if err := uc.authCommandRepo.RevokeToken(ctx, refreshToken); err != nil {
+	if refreshToken == "" {
+		sessionID, ok := middleware.GetSessionIDFromContext(ctx)
+		if !ok {
+			return ErrTokenInvalid
+		}
+		// An impersonation has its own session without refresh tokens: ending it
+		// leaves the admin's session alone
+		if middleware.IsImpersonated(ctx) {
+			return uc.revocationRepo.MarkSessionRevoked(ctx, sessionID, uc.config.Impersonation.TokenTTL)
+		}
+		if err := uc.authCommandRepo.RevokeTokenFamily(ctx, sessionID); err != nil {
+			return err
+		}
+		uc.markSessionRevoked(ctx, sessionID)
+		return nil
+	}
+
+	token, err := uc.authQueryRepo.FindTokenByRefreshTokenHash(ctx, uc.hashToken(refreshToken))
+	if err != nil {
 		return err
 	}
+	if token == nil {
+		uc.log.WithContext(ctx).Warn("Logout with unknown refresh token")
+		return nil
+	}
+
+	if err := uc.authCommandRepo.RevokeToken(ctx, token.RefreshTokenHash); err != nil {
+		return err
+	}
+	uc.markSessionRevoked(ctx, token.FamilyID)
 
 	return nil
 }
@@ -400,7 +780,7 @@ func (uc *AuthUsecase) Logout(ctx context.Context, refreshToken string) error {
 // GetUserFromToken extracts user from JWT token
 func (uc *AuthUsecase) GetUserFromToken(ctx context.Context, tokenString string) (*User, error) {
 	// Validate token
-	claims, err := jwt.ValidateToken(tokenString, uc.jwtSecret)
+	claims, err := jwt.ValidateToken(tokenString, uc.keys)
 	if err != nil {
 		if err == jwt.ErrExpiredToken {
 			return nil, ErrTokenExpired
@@ -429,6 +809,79 @@ func (uc *AuthUsecase) GetUserFromToken(ctx context.Context, tokenString string)
 // RevokeAllTokens revokes all tokens for a user
 func (uc *AuthUsecase) RevokeAllTokens(ctx context.Context, userID uuid.UUID) error {
 	uc.log.WithContext(ctx).Infof("Revoke all tokens for user: %s", userID.String())
-	return uc.authCommandRepo.RevokeAllUserTokens(ctx, userID)
+
+	// Collect active sessions first so their access tokens can be cut off too
+	tokens, err := uc.authQueryRepo.ListUserTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authCommandRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		uc.markSessionRevoked(ctx, token.FamilyID)
+	}
+	return nil
+}
+
+// IsSessionRevoked reports whether access tokens of the session must be rejected.
+// Used by AuthMiddleware on every authenticated request.
+func (uc *AuthUsecase) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	return uc.revocationRepo.IsSessionRevoked(ctx, sessionID)
+}
+
+// markSessionRevoked propagates a revocation to the access-token revocation store
+func (uc *AuthUsecase) markSessionRevoked(ctx context.Context, sessionID uuid.UUID) {
+	if err := uc.revocationRepo.MarkSessionRevoked(ctx, sessionID, uc.accessExpiry); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to mark session %s revoked: %v", sessionID.String(), err)
+	}
+}
+
+// rehashPassword stores a hash of the password made with the configured algorithm.
+// Failures are logged only: the old hash keeps working.
+func (uc *AuthUsecase) rehashPassword(ctx context.Context, user *User, plain string) {
+	passwordHash, err := password.Hash(plain)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to rehash password of %s: %v", user.Email, err)
+		return
+	}
+	if err := uc.userCommandRepo.RehashPassword(ctx, user.ID, passwordHash); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to save rehashed password of %s: %v", user.Email, err)
+		return
+	}
+	user.PasswordHash = passwordHash
+	uc.log.WithContext(ctx).Infof("Password hash upgraded: %s", user.Email)
+}
+
+// PasswordParamsFromConf returns the password hashing parameters of conf.Auth,
+// falling back to password.DefaultParams
+func PasswordParamsFromConf(auth *conf.Auth) password.Params {
+	params := password.DefaultParams()
+	c := auth.GetPasswordHash()
+	if c == nil {
+		return params
+	}
+	if c.Algorithm != "" {
+		params.Algorithm = c.Algorithm
+	}
+	if c.BcryptCost > 0 {
+		params.BcryptCost = int(c.BcryptCost)
+	}
+	if c.Argon2Memory > 0 {
+		params.Argon2Memory = c.Argon2Memory
+	}
+	if c.Argon2Iterations > 0 {
+		params.Argon2Iterations = c.Argon2Iterations
+	}
+	if c.Argon2Parallelism > 0 && c.Argon2Parallelism <= 255 {
+		params.Argon2Parallelism = uint8(c.Argon2Parallelism)
+	}
+	return params
 }
 
+// hashToken returns the keyed hash under which a token is stored
+func (uc *AuthUsecase) hashToken(token string) string {
+	return tokenhash.Hash(token, uc.tokenPepper)
+}
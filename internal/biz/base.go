@@ -12,6 +12,7 @@ import (
 // BaseEntity là base entity cho tất cả các domain models với UUID v7
 type BaseEntity struct {
 	ID        uuid.UUID      `gorm:"type:uuid;primarykey" json:"id"`
+	TenantID  *uuid.UUID     `gorm:"type:uuid;index" json:"tenant_id,omitempty"` // Set from the context on create; nil = shared by all tenants
 	CreatedAt time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -69,8 +70,9 @@ func (b *BaseEntity) IsDeleted() bool {
 
 // SetAuditFields sets CreatedBy/UpdatedBy from context
 // isCreate: true for create operations, false for update operations
+// During impersonation the impersonating admin is recorded, not the impersonated user.
 func (b *BaseEntity) SetAuditFields(ctx context.Context, isCreate bool) {
-	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+	if userID, ok := middleware.GetActorIDFromContext(ctx); ok {
 		if isCreate {
 			b.CreatedBy = &userID
 		}
@@ -78,3 +80,13 @@ func (b *BaseEntity) SetAuditFields(ctx context.Context, isCreate bool) {
 	}
 }
 
+// TenantShared is implemented by models whose rows without a tenant (global reference
+// data such as countries) are visible to every tenant. Tenants can only change their own rows.
+type TenantShared interface {
+	TenantShared() bool
+}
+
+// IsShared reports whether the row is global reference data, shared by all tenants
+func (b *BaseEntity) IsShared() bool {
+	return b.TenantID == nil
+}
@@ -7,7 +7,12 @@ var ProviderSet = wire.NewSet(
 	NewGreeterUsecase,
 	NewUserUsecase,
 	NewAuthConfigFromConf,
+	NewJWTKeySetFromConf,
+	NewBreachedPasswordCheckerFromConf,
+	wire.Struct(new(AuthRepos), "*"),
 	NewAuthUsecase,
+	NewTokenCleanupUsecase,
+	NewRoleUsecase,
 	NewCountryUsecase,
 	NewProvinceUsecase,
 	NewWardUsecase,
@@ -0,0 +1,51 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/pkg/breached"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	ErrPasswordBreached = errors.BadRequest("PASSWORD_BREACHED", "this password has appeared in a data breach, choose a different one")
+)
+
+// BreachedPasswordChecker reports whether a password appears in a breached-password corpus
+type BreachedPasswordChecker interface {
+	Contains(password string) bool
+}
+
+// NewBreachedPasswordCheckerFromConf loads the local corpus of auth.breached_passwords
+// (see package breached). Without a path no password is rejected.
+func NewBreachedPasswordCheckerFromConf(auth *conf.Auth, logger log.Logger) (BreachedPasswordChecker, error) {
+	c := auth.GetBreachedPasswords()
+	if c.GetPath() == "" {
+		return &breached.Index{}, nil
+	}
+
+	minCount := c.MinCount
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	start := time.Now()
+	index, err := breached.LoadPath(c.Path, minCount)
+	if err != nil {
+		return nil, err
+	}
+	log.NewHelper(logger).Infof("Loaded %d breached password hashes (min count %d) from %s in %s",
+		index.Len(), minCount, c.Path, time.Since(start).Round(time.Millisecond))
+	return index, nil
+}
+
+// checkPasswordBreached rejects a new password found in the breached-password corpus
+func checkPasswordBreached(checker BreachedPasswordChecker, newPassword string) error {
+	if checker.Contains(newPassword) {
+		return ErrPasswordBreached
+	}
+	return nil
+}
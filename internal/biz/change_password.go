@@ -0,0 +1,94 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// ChangeMyPasswordRequest changes the password of the current user
+type ChangeMyPasswordRequest struct {
+	CurrentPassword     string
+	NewPassword         string // Must already be validated
+	RevokeOtherSessions bool   // Sign out every session except the current one
+}
+
+// ChangeMyPassword sets a new password for the current user after checking the current one
+func (uc *AuthUsecase) ChangeMyPassword(ctx context.Context, req *ChangeMyPasswordRequest) error {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return ErrTokenInvalid
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return err
+	}
+
+	user, err := uc.userQueryRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// A stolen access token must not be enough to take over the account
+	if ok, _ := password.Verify(req.CurrentPassword, user.PasswordHash); !ok {
+		uc.recordFailedLogin(ctx, user)
+		return ErrInvalidPassword
+	}
+	uc.resetFailedLogins(ctx, user)
+
+	if err := checkPasswordBreached(uc.breachedPasswords, req.NewPassword); err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(ctx, uc.userQueryRepo, uc.config.PasswordPolicy, user, req.NewPassword); err != nil {
+		return err
+	}
+
+	passwordHash, err := password.Hash(req.NewPassword)
+	if err != nil {
+		return errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+	}
+
+	if err := uc.userCommandRepo.UpdatePassword(ctx, userID, passwordHash); err != nil {
+		return err
+	}
+
+	// Reset links sent before the change must not undo it
+	if err := uc.resetRepo.InvalidateUserResetTokens(ctx, userID); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to invalidate reset tokens of user %s: %v", userID.String(), err)
+	}
+
+	if req.RevokeOtherSessions {
+		if err := uc.revokeOtherSessions(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	uc.log.WithContext(ctx).Infof("Password changed by user: %s", userID.String())
+	return nil
+}
+
+// revokeOtherSessions revokes every session of a user except the one of the current access token
+func (uc *AuthUsecase) revokeOtherSessions(ctx context.Context, userID uuid.UUID) error {
+	current, _ := middleware.GetSessionIDFromContext(ctx)
+
+	tokens, err := uc.authQueryRepo.ListUserTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	revoked := make(map[uuid.UUID]bool)
+	for _, token := range tokens {
+		if token.FamilyID == current || revoked[token.FamilyID] {
+			continue
+		}
+		if err := uc.authCommandRepo.RevokeTokenFamily(ctx, token.FamilyID); err != nil {
+			return err
+		}
+		uc.markSessionRevoked(ctx, token.FamilyID)
+		revoked[token.FamilyID] = true
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ var (
 	ErrCountryNotFound      = errors.NotFound("COUNTRY_NOT_FOUND", "country not found")
 	ErrCountryAlreadyExists = errors.Conflict("COUNTRY_ALREADY_EXISTS", "country already exists")
 	ErrInvalidCountryCode   = errors.BadRequest("INVALID_COUNTRY_CODE", "invalid country code format")
+	ErrCountryShared        = errors.Forbidden("COUNTRY_SHARED", "shared countries are read-only")
 )
 
 // Country là domain model cho Quốc gia
@@ -20,7 +21,7 @@ type Country struct {
 	BaseEntity
 
 	// Mã quốc gia (ISO 3166-1 alpha-2)
-	Code string `gorm:"type:varchar(2);uniqueIndex;not null" json:"code"` // VN, US, JP...
+	Code string `gorm:"type:varchar(2);not null" json:"code"` // VN, US, JP... (unique per tenant and among shared countries)
 
 	// Tên quốc gia
 	Name   string `gorm:"type:varchar(255);not null;index" json:"name"`   // Việt Nam
@@ -44,10 +45,15 @@ type Country struct {
 	Population int64  `gorm:"type:bigint" json:"population,omitempty"`
 
 	// Metadata
-	ISO3166Alpha3  string `gorm:"type:varchar(3);uniqueIndex" json:"iso3166_alpha3,omitempty"`  // VNM
+	ISO3166Alpha3  string `gorm:"type:varchar(3)" json:"iso3166_alpha3,omitempty"`  // VNM
 	ISO3166Numeric string `gorm:"type:varchar(3)" json:"iso3166_numeric,omitempty"`            // 704
 }
 
+// TenantShared: countries without a tenant are reference data visible to every tenant
+func (Country) TenantShared() bool {
+	return true
+}
+
 // CountryCommandRepo là repository interface cho write operations
 type CountryCommandRepo interface {
 	Save(context.Context, *Country) (*Country, error)
@@ -124,6 +130,10 @@ func (uc *CountryUsecase) UpdateCountry(ctx context.Context, country *Country) (
 		return nil, ErrInvalidCountryCode
 	}
 
+	if err := uc.checkOwnCountry(ctx, country.ID); err != nil {
+		return nil, err
+	}
+
 	// Set audit fields from context
 	country.SetAuditFields(ctx, false)
 
@@ -133,9 +143,24 @@ func (uc *CountryUsecase) UpdateCountry(ctx context.Context, country *Country) (
 // DeleteCountry deletes a country (Command)
 func (uc *CountryUsecase) DeleteCountry(ctx context.Context, id uuid.UUID) error {
 	uc.log.WithContext(ctx).Infof("DeleteCountry: %s", id.String())
+	if err := uc.checkOwnCountry(ctx, id); err != nil {
+		return err
+	}
 	return uc.commandRepo.Delete(ctx, id)
 }
 
+// checkOwnCountry allows changes to the tenant's own countries only, shared ones are read-only
+func (uc *CountryUsecase) checkOwnCountry(ctx context.Context, id uuid.UUID) error {
+	country, err := uc.GetCountry(ctx, id)
+	if err != nil {
+		return err
+	}
+	if country.IsShared() {
+		return ErrCountryShared
+	}
+	return nil
+}
+
 // GetCountry gets a country by ID (Query)
 func (uc *CountryUsecase) GetCountry(ctx context.Context, id uuid.UUID) (*Country, error) {
 	country, err := uc.queryRepo.FindByID(ctx, id)
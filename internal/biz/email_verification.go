@@ -0,0 +1,164 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrEmailNotVerified         = errors.Forbidden("EMAIL_NOT_VERIFIED", "email address is not verified")
+	ErrVerificationTokenInvalid = errors.BadRequest("VERIFICATION_TOKEN_INVALID", "verification token is invalid or expired")
+)
+
+// Email verification modes (auth.email_verification.mode)
+const (
+	EmailVerificationModeNone     = "none"     // Verification emails are sent but not enforced
+	EmailVerificationModeBlock    = "block"    // Unverified users cannot log in
+	EmailVerificationModeRestrict = "restrict" // Unverified users get the restricted role in their tokens
+)
+
+// EmailVerificationToken is a single-use token emailed to confirm an email address
+type EmailVerificationToken struct {
+	BaseEntity
+
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // HMAC-SHA256 of the token
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"type:timestamp" json:"used_at,omitempty"`
+}
+
+// EmailVerificationConfig configures email verification
+type EmailVerificationConfig struct {
+	Mode           string
+	RestrictedRole string
+	TokenTTL       time.Duration
+	URL            string // "{token}" is replaced by the verification token
+}
+
+// EmailVerificationCommandRepo for write operations
+type EmailVerificationCommandRepo interface {
+	SaveVerificationToken(context.Context, *EmailVerificationToken) (*EmailVerificationToken, error)
+	// UseVerificationToken atomically marks an unused, unexpired token (by hash) as used.
+	// Returns nil if no such token exists.
+	UseVerificationToken(context.Context, string) (*EmailVerificationToken, error)
+	// InvalidateUserVerificationTokens marks every unused token of a user as used
+	InvalidateUserVerificationTokens(context.Context, uuid.UUID) error
+}
+
+// VerifyEmail marks the email of the token's user as verified
+func (uc *AuthUsecase) VerifyEmail(ctx context.Context, token string) (*User, error) {
+	// The link carries no tenant, the token names it
+	verificationToken, err := uc.verificationRepo.UseVerificationToken(tenant.WithAllTenants(ctx), uc.hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if verificationToken == nil {
+		return nil, ErrVerificationTokenInvalid
+	}
+	ctx = inRecordTenant(ctx, &verificationToken.BaseEntity)
+
+	if err := uc.userCommandRepo.MarkEmailVerified(ctx, verificationToken.UserID); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userQueryRepo.FindByID(ctx, verificationToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.WithContext(ctx).Infof("Email verified: %s", user.Email)
+	return user, nil
+}
+
+// ResendVerificationEmail sends a new verification link to an unverified account.
+// It succeeds whether or not the email is registered so accounts cannot be enumerated.
+func (uc *AuthUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
+	user, err := uc.userQueryRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive() || user.IsEmailVerified() {
+		uc.log.WithContext(ctx).Infof("Verification email not resent for: %s", email)
+		return nil
+	}
+
+	uc.sendVerificationEmail(ctx, user)
+	return nil
+}
+
+// sendVerificationEmail issues a new verification token and emails it.
+// Failures are logged only: the user can ask for the email again.
+func (uc *AuthUsecase) sendVerificationEmail(ctx context.Context, user *User) {
+	// Only the latest link works
+	if err := uc.verificationRepo.InvalidateUserVerificationTokens(ctx, user.ID); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to invalidate verification tokens of %s: %v", user.Email, err)
+		return
+	}
+
+	token, err := generateOneTimeToken()
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to generate verification token: %v", err)
+		return
+	}
+
+	verificationToken := &EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: uc.hashToken(token),
+		ExpiresAt: time.Now().Add(uc.config.EmailVerification.TokenTTL),
+	}
+	verificationToken.SetAuditFields(ctx, true)
+	if verificationToken.CreatedBy == nil {
+		verificationToken.CreatedBy = &user.ID
+		verificationToken.UpdatedBy = &user.ID
+	}
+
+	if _, err := uc.verificationRepo.SaveVerificationToken(ctx, verificationToken); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to save verification token of %s: %v", user.Email, err)
+		return
+	}
+
+	link := token
+	if uc.config.EmailVerification.URL != "" {
+		link = strings.ReplaceAll(uc.config.EmailVerification.URL, "{token}", token)
+	}
+	msg := &MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nPlease confirm your email address using the link below within %s:\n\n%s\n",
+			user.GetDisplayName(), uc.config.EmailVerification.TokenTTL, link,
+		),
+	}
+	if err := uc.mailer.Send(ctx, msg); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to send verification email to %s: %v", user.Email, err)
+	}
+}
+
+// checkEmailVerified rejects unverified users when verification blocks login
+func (uc *AuthUsecase) checkEmailVerified(user *User) error {
+	if uc.config.EmailVerification.Mode == EmailVerificationModeBlock && !user.IsEmailVerified() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// tokenRole returns the role put in access tokens: unverified users get the
+// restricted role when verification restricts access
+func (uc *AuthUsecase) tokenRole(user *User) string {
+	if uc.isEmailRestricted(user) {
+		return uc.config.EmailVerification.RestrictedRole
+	}
+	return user.Role
+}
+
+// isEmailRestricted reports whether the user only gets the restricted role until the email is verified
+func (uc *AuthUsecase) isEmailRestricted(user *User) bool {
+	return uc.config.EmailVerification.Mode == EmailVerificationModeRestrict && !user.IsEmailVerified()
+}
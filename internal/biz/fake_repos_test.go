@@ -0,0 +1,373 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memStore is an in-memory database shared by the fake repositories.
+// The fakes embed the repo interfaces, calling a method a test does not need panics.
+type memStore struct {
+	mu         sync.Mutex
+	users      map[uuid.UUID]*User
+	identities []*UserIdentity
+	states     []*OIDCLoginState
+	tokens     []*AuthToken
+	logins     []*LoginHistory
+	mfa        map[uuid.UUID]*MFASecret
+	recovery   map[string]bool        // Unused recovery code hashes
+	revoked    map[uuid.UUID]bool     // Revoked sessions
+	roles      map[uuid.UUID][]string // Roles assigned on top of the primary role
+	audit      []*AuditLog
+	resets     []*PasswordResetToken
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		users:    make(map[uuid.UUID]*User),
+		mfa:      make(map[uuid.UUID]*MFASecret),
+		recovery: make(map[string]bool),
+		revoked:  make(map[uuid.UUID]bool),
+		roles:    make(map[uuid.UUID][]string),
+	}
+}
+
+func (s *memStore) addUser(user *User) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = user.BeforeCreate(nil)
+	s.users[user.ID] = user
+	return user
+}
+
+func (s *memStore) findUser(match func(*User) bool) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, user := range s.users {
+		if match(user) {
+			u := *user
+			return &u
+		}
+	}
+	return nil
+}
+
+type fakeUserRepo struct {
+	UserQueryRepo
+	UserCommandRepo
+	s *memStore
+}
+
+func (r *fakeUserRepo) FindByID(_ context.Context, id uuid.UUID) (*User, error) {
+	return r.s.findUser(func(u *User) bool { return u.ID == id }), nil
+}
+
+func (r *fakeUserRepo) FindByEmail(_ context.Context, email string) (*User, error) {
+	return r.s.findUser(func(u *User) bool { return strings.EqualFold(u.Email, email) }), nil
+}
+
+func (r *fakeUserRepo) FindByUsername(_ context.Context, username string) (*User, error) {
+	return r.s.findUser(func(u *User) bool { return u.Username == username }), nil
+}
+
+func (r *fakeUserRepo) Save(_ context.Context, user *User) (*User, error) {
+	u := *user
+	r.s.addUser(&u)
+	*user = u
+	return user, nil
+}
+
+func (r *fakeUserRepo) Update(_ context.Context, user *User) (*User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u := *user
+	r.s.users[user.ID] = &u
+	return user, nil
+}
+
+func (r *fakeUserRepo) UpdateLastLogin(_ context.Context, id uuid.UUID, ip string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	r.s.users[id].LastLoginAt = &now
+	r.s.users[id].LastLoginIP = ip
+	return nil
+}
+
+func (r *fakeUserRepo) MarkEmailVerified(_ context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	r.s.users[id].EmailVerifiedAt = &now
+	return nil
+}
+
+func (r *fakeUserRepo) IncrementFailedLogins(_ context.Context, id uuid.UUID) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.users[id].FailedLoginAttempts++
+	return r.s.users[id].FailedLoginAttempts, nil
+}
+
+func (r *fakeUserRepo) LockUntil(_ context.Context, id uuid.UUID, until time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.users[id].LockedUntil = &until
+	return nil
+}
+
+func (r *fakeUserRepo) ResetFailedLogins(_ context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.users[id].FailedLoginAttempts = 0
+	r.s.users[id].LockedUntil = nil
+	return nil
+}
+
+type fakeOIDCRepo struct {
+	s *memStore
+}
+
+func (r *fakeOIDCRepo) SaveLoginState(_ context.Context, state *OIDCLoginState) (*OIDCLoginState, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_ = state.BeforeCreate(nil)
+	r.s.states = append(r.s.states, state)
+	return state, nil
+}
+
+func (r *fakeOIDCRepo) UseLoginState(_ context.Context, stateHash string) (*OIDCLoginState, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, state := range r.s.states {
+		if state.StateHash == stateHash && state.UsedAt == nil && time.Now().Before(state.ExpiresAt) {
+			now := time.Now()
+			state.UsedAt = &now
+			return state, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOIDCRepo) SaveIdentity(_ context.Context, identity *UserIdentity) (*UserIdentity, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_ = identity.BeforeCreate(nil)
+	r.s.identities = append(r.s.identities, identity)
+	return identity, nil
+}
+
+func (r *fakeOIDCRepo) UpdateIdentityLastLogin(_ context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeOIDCRepo) FindIdentity(_ context.Context, provider, subject string) (*UserIdentity, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, identity := range r.s.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeAuthRepo struct {
+	AuthCommandRepo
+	AuthQueryRepo
+	s *memStore
+}
+
+func (r *fakeAuthRepo) RevokeTokenFamily(_ context.Context, familyID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, token := range r.s.tokens {
+		if token.FamilyID == familyID {
+			token.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (r *fakeAuthRepo) IsSessionActive(_ context.Context, sessionID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, token := range r.s.tokens {
+		if token.FamilyID == sessionID && !token.Revoked && time.Now().Before(token.RefreshExpiresAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeAuthRepo) SaveToken(_ context.Context, token *AuthToken) (*AuthToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_ = token.BeforeCreate(nil)
+	r.s.tokens = append(r.s.tokens, token)
+	return token, nil
+}
+
+type fakeAuditRepo struct {
+	s *memStore
+}
+
+func (r *fakeAuditRepo) SaveAuditLog(_ context.Context, entry *AuditLog) (*AuditLog, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.audit = append(r.s.audit, entry)
+	return entry, nil
+}
+
+type fakeResetRepo struct {
+	PasswordResetCommandRepo
+	s *memStore
+}
+
+func (r *fakeResetRepo) InvalidateUserResetTokens(_ context.Context, userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	for _, token := range r.s.resets {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeResetRepo) SaveResetToken(_ context.Context, token *PasswordResetToken) (*PasswordResetToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_ = token.BeforeCreate(nil)
+	r.s.resets = append(r.s.resets, token)
+	return token, nil
+}
+
+type fakeRevocationRepo struct {
+	s *memStore
+}
+
+func (r *fakeRevocationRepo) IsSessionRevoked(_ context.Context, sessionID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.revoked[sessionID], nil
+}
+
+func (r *fakeRevocationRepo) MarkSessionRevoked(_ context.Context, sessionID uuid.UUID, _ time.Duration) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.revoked[sessionID] = true
+	return nil
+}
+
+// fakeMFARepo: only the users given a secret in the store have MFA
+type fakeMFARepo struct {
+	MFACommandRepo
+	s *memStore
+}
+
+func (r *fakeMFARepo) FindSecretByUserID(_ context.Context, userID uuid.UUID) (*MFASecret, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.mfa[userID], nil
+}
+
+func (r *fakeMFARepo) UseRecoveryCode(_ context.Context, _ uuid.UUID, codeHash string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if !r.s.recovery[codeHash] {
+		return false, nil
+	}
+	delete(r.s.recovery, codeHash)
+	return true, nil
+}
+
+// fakeRoleRepo: users hold their primary role and the roles assigned in the store, without permissions
+type fakeRoleRepo struct {
+	RoleQueryRepo
+	s *memStore
+}
+
+func (r *fakeRoleRepo) ListUserRoles(_ context.Context, userID uuid.UUID) ([]*Role, error) {
+	user := r.s.findUser(func(u *User) bool { return u.ID == userID })
+	if user == nil {
+		return nil, nil
+	}
+	roles := []*Role{{Name: user.Role}}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, name := range r.s.roles[userID] {
+		roles = append(roles, &Role{Name: name})
+	}
+	return roles, nil
+}
+
+func (r *fakeRoleRepo) ListUserPermissions(context.Context, uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
+type fakeLoginHistoryRepo struct {
+	s *memStore
+}
+
+func (r *fakeLoginHistoryRepo) SaveLoginHistory(_ context.Context, entry *LoginHistory) (*LoginHistory, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_ = entry.BeforeCreate(nil)
+	r.s.logins = append(r.s.logins, entry)
+	return entry, nil
+}
+
+func (r *fakeLoginHistoryRepo) ListUserLoginHistory(_ context.Context, userID uuid.UUID, limit int) ([]*LoginHistory, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var entries []*LoginHistory
+	for i := len(r.s.logins) - 1; i >= 0 && len(entries) < limit; i-- {
+		if entry := r.s.logins[i]; entry.UserID != nil && *entry.UserID == userID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (r *fakeLoginHistoryRepo) ListKnownDevices(_ context.Context, userID uuid.UUID) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var devices []string
+	for _, entry := range r.s.logins {
+		if entry.Success && entry.UserID != nil && *entry.UserID == userID {
+			devices = append(devices, entry.DeviceFingerprint)
+		}
+	}
+	return devices, nil
+}
+
+// newFakeAuthRepos wires every fake around one store
+func newFakeAuthRepos(s *memStore) AuthRepos {
+	users := &fakeUserRepo{s: s}
+	oidcRepo := &fakeOIDCRepo{s: s}
+	loginHistory := &fakeLoginHistoryRepo{s: s}
+	mfa := &fakeMFARepo{s: s}
+	auth := &fakeAuthRepo{s: s}
+	return AuthRepos{
+		UserQuery:           users,
+		UserCommand:         users,
+		AuthCommand:         auth,
+		AuthQuery:           auth,
+		Revocation:          &fakeRevocationRepo{s: s},
+		MFACommand:          mfa,
+		MFAQuery:            mfa,
+		PasswordReset:       &fakeResetRepo{s: s},
+		OIDCCommand:         oidcRepo,
+		OIDCQuery:           oidcRepo,
+		RoleQuery:           &fakeRoleRepo{s: s},
+		Audit:               &fakeAuditRepo{s: s},
+		LoginHistoryCommand: loginHistory,
+		LoginHistoryQuery:   loginHistory,
+	}
+}
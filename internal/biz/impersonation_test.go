@@ -0,0 +1,146 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/gofrs/uuid/v5"
+)
+
+// impersonationContext is the context of an admin impersonating a user whose token
+// carries every admin permission
+func impersonationContext() context.Context {
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, uuid.Must(uuid.NewV4()))
+	ctx = context.WithValue(ctx, middleware.PermissionsKey, []string{PermissionUserAdmin, PermissionRoleAdmin})
+	return context.WithValue(ctx, middleware.ImpersonatorIDKey, uuid.Must(uuid.NewV4()))
+}
+
+func TestAdminChangesAreForbiddenWhileImpersonating(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	// No repositories: the request must be rejected before any of them is used
+	users := NewUserUsecase(nil, nil, nil, nil, nil, NewAuthConfigFromConf(nil), logger)
+	roles := NewRoleUsecase(nil, nil, nil, nil, logger)
+	target := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"ChangePassword", func(ctx context.Context) error {
+			return users.ChangePassword(ctx, target, "c0rrect-h0rse-Battery", "203.0.113.25")
+		}},
+		{"UnlockUser", func(ctx context.Context) error { return users.UnlockUser(ctx, target) }},
+		{"CreateRole", func(ctx context.Context) error {
+			_, err := roles.CreateRole(ctx, &Role{Name: "auditor"}, []string{PermissionAuditRead})
+			return err
+		}},
+		{"UpdateRole", func(ctx context.Context) error {
+			_, err := roles.UpdateRole(ctx, target, "auditors", []string{PermissionAuditRead})
+			return err
+		}},
+		{"DeleteRole", func(ctx context.Context) error { return roles.DeleteRole(ctx, target) }},
+		{"AssignUserRole", func(ctx context.Context) error {
+			_, err := roles.AssignUserRole(ctx, target, RoleAdmin)
+			return err
+		}},
+		{"RemoveUserRole", func(ctx context.Context) error {
+			_, err := roles.RemoveUserRole(ctx, target, RoleAdmin)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(impersonationContext())
+			if !errors.Is(err, ErrImpersonationRestricted) {
+				t.Fatalf("err = %v, want IMPERSONATION_RESTRICTED", err)
+			}
+			if code := kerrors.FromError(err).Code; code != http.StatusForbidden {
+				t.Fatalf("code = %d, want %d", code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestImpersonationHasItsOwnSession(t *testing.T) {
+	store := newMemStore()
+	keys := jwt.NewHMACKeySet([]byte("test-secret"))
+	uc := NewAuthUsecase(newFakeAuthRepos(store), nil, nil, nil, NewAuthConfigFromConf(nil), keys, log.NewStdLogger(io.Discard))
+	admin := store.addUser(&User{Email: "admin@example.com", Username: "admin", Role: RoleAdmin})
+	target := store.addUser(&User{Email: "an@example.com", Username: "an", Role: RoleUser})
+
+	adminLogin, err := uc.issueSession(context.Background(), admin, LoginMethodPassword, "203.0.113.25", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminClaims, err := jwt.ValidateToken(adminLogin.AccessToken, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminCtx := context.WithValue(context.Background(), middleware.UserIDKey, admin.ID)
+	adminCtx = context.WithValue(adminCtx, middleware.UserEmailKey, admin.Email)
+	adminCtx = context.WithValue(adminCtx, middleware.PermissionsKey, []string{PermissionUserImpersonate})
+	adminCtx = context.WithValue(adminCtx, middleware.SessionIDKey, adminClaims.SessionID)
+
+	// impersonate returns an impersonation token and the context AuthMiddleware builds for it
+	impersonate := func(t *testing.T) (string, context.Context) {
+		t.Helper()
+		resp, err := uc.Impersonate(adminCtx, &ImpersonateRequest{UserID: target.ID, Reason: "support ticket 4711"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, err := jwt.ValidateToken(resp.AccessToken, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if claims.SessionID == adminClaims.SessionID || claims.Actor.SessionID != adminClaims.SessionID.String() {
+			t.Fatalf("impersonation session = %s (admin's %s), act.sid = %s, want its own session inside the admin's",
+				claims.SessionID, adminClaims.SessionID, claims.Actor.SessionID)
+		}
+		ctx := context.WithValue(context.Background(), middleware.UserIDKey, target.ID)
+		ctx = context.WithValue(ctx, middleware.SessionIDKey, claims.SessionID)
+		ctx = context.WithValue(ctx, middleware.ImpersonatorIDKey, admin.ID)
+		return resp.AccessToken, ctx
+	}
+	active := func(t *testing.T, token string) bool {
+		t.Helper()
+		result, err := uc.IntrospectToken(context.Background(), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Active
+	}
+
+	t.Run("logout ends only the impersonation", func(t *testing.T) {
+		token, ctx := impersonate(t)
+		if !active(t, token) {
+			t.Fatal("impersonation token inactive before logout")
+		}
+		if err := uc.Logout(ctx, ""); err != nil {
+			t.Fatal(err)
+		}
+		if active(t, token) {
+			t.Fatal("impersonation token still active after its logout")
+		}
+		if !active(t, adminLogin.AccessToken) || store.revoked[adminClaims.SessionID] {
+			t.Fatal("logging out of the impersonation ended the admin's session")
+		}
+	})
+
+	t.Run("admin logout ends the impersonation", func(t *testing.T) {
+		token, _ := impersonate(t)
+		if err := uc.Logout(adminCtx, ""); err != nil {
+			t.Fatal(err)
+		}
+		if active(t, adminLogin.AccessToken) || active(t, token) {
+			t.Fatal("tokens still active after the admin's logout")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestIntrospectAccessToken(t *testing.T) {
+	const secret = "c0rrect-h0rse-Battery"
+	hash, err := password.Hash(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(user *User)
+		wantActive bool
+	}{
+		{"active user", func(*User) {}, true},
+		{"locked user", func(user *User) {
+			until := time.Now().Add(15 * time.Minute)
+			user.LockedUntil = &until
+		}, false},
+		{"lock ended", func(user *User) {
+			until := time.Now().Add(-time.Minute)
+			user.LockedUntil = &until
+		}, true},
+		{"inactive user", func(user *User) { user.Status = UserStatusInactive }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore()
+			uc := NewAuthUsecase(newFakeAuthRepos(store), nil, nil, nil, NewAuthConfigFromConf(nil),
+				jwt.NewHMACKeySet([]byte("test-secret")), log.NewStdLogger(io.Discard))
+			now := time.Now()
+			user := store.addUser(&User{
+				Email:           "introspect@example.com",
+				Username:        "introspect",
+				PasswordHash:    hash,
+				Role:            RoleUser,
+				EmailVerifiedAt: &now,
+			})
+			login, err := uc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: secret})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			store.mu.Lock()
+			tt.modify(store.users[user.ID])
+			store.mu.Unlock()
+
+			result, err := uc.IntrospectToken(context.Background(), login.AccessToken)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Active != tt.wantActive {
+				t.Fatalf("active = %v, want %v", result.Active, tt.wantActive)
+			}
+			if result.Active && (result.UserID != user.ID || result.TokenType != TokenTypeAccess) {
+				t.Fatalf("introspection = %+v", result)
+			}
+			if !result.Active && *result != (TokenIntrospection{}) {
+				t.Fatalf("inactive token reveals more than active=false: %+v", result)
+			}
+		})
+	}
+}
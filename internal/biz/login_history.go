@@ -0,0 +1,161 @@
+package biz
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/device"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Login methods
+const (
+	LoginMethodPassword = "password"
+	LoginMethodMFA      = "mfa" // Password or OIDC login completed with the second factor
+	LoginMethodOIDC     = "oidc"
+)
+
+// Reasons of failed login attempts
+const (
+	LoginFailureUnknownUser      = "unknown_user"
+	LoginFailureInvalidPassword  = "invalid_password"
+	LoginFailureAccountLocked    = "account_locked"
+	LoginFailureUserInactive     = "user_inactive"
+	LoginFailureEmailNotVerified = "email_not_verified"
+	LoginFailureInvalidMFACode   = "invalid_mfa_code"
+)
+
+const (
+	defaultLoginHistoryLimit = 20
+	maxLoginHistoryLimit     = 100
+	newDeviceNotifyTimeout   = 30 * time.Second
+)
+
+// LoginHistory is one login attempt, successful or not
+type LoginHistory struct {
+	BaseEntity
+
+	UserID            *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`     // nil when the account does not exist
+	Identifier        string     `gorm:"type:varchar(255);not null" json:"identifier"` // Email or username that was entered
+	Method            string     `gorm:"type:varchar(20);not null" json:"method"`
+	Success           bool       `gorm:"not null" json:"success"`
+	FailureReason     string     `gorm:"type:varchar(50)" json:"failure_reason,omitempty"`
+	IPAddress         string     `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent         string     `gorm:"type:text" json:"user_agent"`
+	DeviceName        string     `gorm:"type:varchar(100)" json:"device_name"`             // e.g. "Chrome on Windows"
+	DeviceFingerprint string     `gorm:"type:varchar(32);index" json:"device_fingerprint"` // UA family + IP subnet
+	NewDevice         bool       `gorm:"not null;default:false" json:"new_device"`         // First successful login from this device
+	SessionID         *uuid.UUID `gorm:"type:uuid" json:"session_id,omitempty"`
+}
+
+// TableName keeps the singular table name used by the migration
+func (LoginHistory) TableName() string {
+	return "login_history"
+}
+
+// LoginHistoryCommandRepo for write operations
+type LoginHistoryCommandRepo interface {
+	SaveLoginHistory(context.Context, *LoginHistory) (*LoginHistory, error)
+}
+
+// LoginHistoryQueryRepo for read operations
+type LoginHistoryQueryRepo interface {
+	// ListUserLoginHistory returns the latest login attempts of a user, newest first
+	ListUserLoginHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*LoginHistory, error)
+	// ListKnownDevices returns the fingerprints of every device the user has logged in from
+	ListKnownDevices(context.Context, uuid.UUID) ([]string, error)
+}
+
+// LoginNotifier tells users about security events on their account (email, webhook)
+type LoginNotifier interface {
+	NotifyNewDevice(context.Context, *User, *LoginHistory) error
+}
+
+// ListMyLoginHistory lists the latest login attempts of the current user, including failures
+func (uc *AuthUsecase) ListMyLoginHistory(ctx context.Context, limit int) ([]*LoginHistory, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrTokenInvalid
+	}
+	if limit <= 0 {
+		limit = defaultLoginHistoryLimit
+	}
+	if limit > maxLoginHistoryLimit {
+		limit = maxLoginHistoryLimit
+	}
+	return uc.loginHistoryQueryRepo.ListUserLoginHistory(ctx, userID, limit)
+}
+
+// recordLoginFailure records a failed attempt; user is nil when no account matched the identifier.
+// Errors are logged only, login history must never break a login.
+func (uc *AuthUsecase) recordLoginFailure(ctx context.Context, user *User, identifier, method, reason, ip, userAgent string) {
+	entry := newLoginHistory(identifier, method, ip, userAgent)
+	entry.FailureReason = reason
+	if user != nil {
+		entry.UserID = &user.ID
+		entry.CreatedBy = &user.ID
+		entry.UpdatedBy = &user.ID
+	}
+	if _, err := uc.loginHistoryCommandRepo.SaveLoginHistory(ctx, entry); err != nil {
+		uc.log.WithContext(ctx).Warnf("Failed to record failed login of %s: %v", identifier, err)
+	}
+}
+
+// recordLoginSuccess records a new session and notifies the user when it comes from a new device
+func (uc *AuthUsecase) recordLoginSuccess(ctx context.Context, user *User, method, ip, userAgent string, sessionID uuid.UUID) {
+	entry := newLoginHistory(user.Email, method, ip, userAgent)
+	entry.Success = true
+	entry.UserID = &user.ID
+	entry.SessionID = &sessionID
+	entry.CreatedBy = &user.ID
+	entry.UpdatedBy = &user.ID
+
+	known, err := uc.loginHistoryQueryRepo.ListKnownDevices(ctx, user.ID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("Failed to list known devices of %s: %v", user.Email, err)
+	} else {
+		// The very first login has nothing to compare with
+		entry.NewDevice = len(known) > 0 && !slices.Contains(known, entry.DeviceFingerprint)
+	}
+
+	if _, err := uc.loginHistoryCommandRepo.SaveLoginHistory(ctx, entry); err != nil {
+		uc.log.WithContext(ctx).Warnf("Failed to record login of %s: %v", user.Email, err)
+	}
+
+	if entry.NewDevice {
+		uc.log.WithContext(ctx).Infof("Security event: login of %s from new device %s (%s)", user.Email, entry.DeviceName, ip)
+		uc.notifyNewDevice(ctx, user, entry)
+	}
+}
+
+// notifyNewDevice sends the notification in the background so a slow webhook or
+// mail server does not delay the login
+func (uc *AuthUsecase) notifyNewDevice(ctx context.Context, user *User, entry *LoginHistory) {
+	if uc.loginNotifier == nil {
+		return
+	}
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), newDeviceNotifyTimeout)
+	go func() {
+		defer cancel()
+		if err := uc.loginNotifier.NotifyNewDevice(notifyCtx, user, entry); err != nil {
+			uc.log.WithContext(notifyCtx).Errorf("Failed to send new device notification to %s: %v", user.Email, err)
+		}
+	}()
+}
+
+func newLoginHistory(identifier, method, ip, userAgent string) *LoginHistory {
+	if len(identifier) > 255 {
+		identifier = identifier[:255]
+	}
+	dev := device.Identify(userAgent, ip)
+	return &LoginHistory{
+		Identifier:        identifier,
+		Method:            method,
+		IPAddress:         ip,
+		UserAgent:         userAgent,
+		DeviceName:        dev.Name,
+		DeviceFingerprint: dev.Fingerprint,
+	}
+}
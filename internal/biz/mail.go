@@ -0,0 +1,15 @@
+package biz
+
+import "context"
+
+// MailMessage is a plain-text email
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails (SMTP in production, an outbox directory for local development and tests)
+type Mailer interface {
+	Send(context.Context, *MailMessage) error
+}
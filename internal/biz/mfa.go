@@ -0,0 +1,374 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/go-kratos/kratos-layout/internal/pkg/totp"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrMFAInvalidCode    = errors.Unauthorized("MFA_INVALID_CODE", "invalid MFA code")
+	ErrMFANotEnrolled    = errors.BadRequest("MFA_NOT_ENROLLED", "MFA is not enrolled")
+	ErrMFAAlreadyEnabled = errors.Conflict("MFA_ALREADY_ENABLED", "MFA is already enabled")
+)
+
+const (
+	// recoveryCodeCount is the number of recovery codes generated at enrollment
+	recoveryCodeCount = 10
+	// totpSkew accepts codes from one step before/after the current one (clock drift)
+	totpSkew = 1
+)
+
+// MFASecret is the TOTP enrollment of a user. It is pending until the first code is confirmed.
+type MFASecret struct {
+	BaseEntity
+
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	SecretEncrypted string     `gorm:"type:text;not null" json:"-"` // AES-GCM encrypted base32 secret
+	Enabled         bool       `gorm:"default:false" json:"enabled"`
+	ConfirmedAt     *time.Time `gorm:"type:timestamp" json:"confirmed_at,omitempty"`
+	LastUsedStep    int64      `gorm:"not null;default:0" json:"-"` // Last accepted TOTP step, blocks code replay
+}
+
+// MFARecoveryCode is a one-time code that replaces a TOTP code when the authenticator is lost
+type MFARecoveryCode struct {
+	BaseEntity
+
+	UserID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash string     `gorm:"type:varchar(64);not null;index" json:"-"` // HMAC-SHA256 of the code
+	UsedAt   *time.Time `gorm:"type:timestamp" json:"used_at,omitempty"`
+}
+
+// MFAEnrollment is shown to the user once to set up an authenticator app
+type MFAEnrollment struct {
+	Secret     string
+	OTPAuthURI string
+}
+
+// VerifyMFARequest completes a login that was answered with an MFA challenge
+type VerifyMFARequest struct {
+	MFAToken     string
+	Code         string
+	RecoveryCode string
+	IP           string
+	UserAgent    string
+}
+
+// MFACommandRepo for write operations
+type MFACommandRepo interface {
+	// SaveSecret stores a pending enrollment, replacing any previous pending one
+	SaveSecret(context.Context, *MFASecret) (*MFASecret, error)
+	// EnableMFA confirms the enrollment and replaces the user's recovery codes
+	EnableMFA(context.Context, uuid.UUID, int64, []*MFARecoveryCode) error
+	DisableMFA(context.Context, uuid.UUID) error
+	// UseStep records an accepted TOTP step; returns false if it (or a later step) was already used
+	UseStep(context.Context, uuid.UUID, int64) (bool, error)
+	// UseRecoveryCode marks an unused recovery code (by hash) as used; returns false if none matched
+	UseRecoveryCode(context.Context, uuid.UUID, string) (bool, error)
+}
+
+// MFAQueryRepo for read operations
+type MFAQueryRepo interface {
+	FindSecretByUserID(context.Context, uuid.UUID) (*MFASecret, error)
+}
+
+// EnrollMFA starts TOTP enrollment for a user.
+// The enrollment stays pending until ConfirmMFAEnrollment succeeds.
+func (uc *AuthUsecase) EnrollMFA(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error) {
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userQueryRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := uc.mfaQueryRepo.FindSecretByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if secret != nil && secret.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return uc.startMFAEnrollment(ctx, user)
+}
+
+// ConfirmMFAEnrollment enables MFA after the first valid code and returns the recovery codes
+func (uc *AuthUsecase) ConfirmMFAEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	secret, err := uc.mfaQueryRepo.FindSecretByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, ErrMFANotEnrolled
+	}
+	if secret.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return uc.confirmMFAEnrollment(ctx, secret, code)
+}
+
+// VerifyMFA checks the second factor of a login and issues the session tokens.
+// For a mandatory enrollment challenge the code also confirms the enrollment.
+func (uc *AuthUsecase) VerifyMFA(ctx context.Context, req *VerifyMFARequest) (*LoginResponse, error) {
+	claims, err := jwt.ValidateChallengeToken(req.MFAToken, uc.keys, jwt.PurposeMFA, jwt.PurposeMFAEnroll)
+	if err != nil {
+		if err == jwt.ErrExpiredToken {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrTokenInvalid
+	}
+	ctx = tenant.NewContext(ctx, tenant.OrDefault(&claims.TenantID))
+
+	user, err := uc.userQueryRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, errors.Forbidden("USER_INACTIVE", "user account is inactive")
+	}
+
+	// Wrong codes lock the account like wrong passwords. A challenge issued before the
+	// lock is never accepted again, a new login is needed once the lock ends.
+	if err := uc.checkAccountLock(user); err != nil {
+		uc.recordLoginFailure(ctx, user, user.Email, LoginMethodMFA, LoginFailureAccountLocked, req.IP, req.UserAgent)
+		return nil, err
+	}
+	if claims.IssuedAt != nil && lockedSince(user, claims.IssuedAt.Time) {
+		return nil, ErrTokenInvalid
+	}
+
+	secret, err := uc.mfaQueryRepo.FindSecretByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, ErrMFANotEnrolled
+	}
+
+	var recoveryCodes []string
+	switch {
+	case secret.Enabled:
+		if err := uc.verifySecondFactor(ctx, secret, req.Code, req.RecoveryCode); err != nil {
+			if errors.Is(err, ErrMFAInvalidCode) {
+				uc.recordFailedLogin(ctx, user)
+				uc.recordLoginFailure(ctx, user, user.Email, LoginMethodMFA, LoginFailureInvalidMFACode, req.IP, req.UserAgent)
+			}
+			return nil, err
+		}
+	case claims.Purpose == jwt.PurposeMFAEnroll:
+		recoveryCodes, err = uc.confirmMFAEnrollment(ctx, secret, req.Code)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrMFANotEnrolled
+	}
+	uc.resetFailedLogins(ctx, user)
+
+	resp, err := uc.issueSession(ctx, user, LoginMethodMFA, req.IP, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// DisableMFA removes the TOTP enrollment and recovery codes of a user after checking a current code
+func (uc *AuthUsecase) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := forbidImpersonation(ctx); err != nil {
+		return err
+	}
+
+	secret, err := uc.mfaQueryRepo.FindSecretByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if secret == nil || !secret.Enabled {
+		return ErrMFANotEnrolled
+	}
+
+	if err := uc.verifyTOTP(ctx, secret, code); err != nil {
+		return err
+	}
+
+	if err := uc.mfaCommandRepo.DisableMFA(ctx, userID); err != nil {
+		return err
+	}
+	uc.log.WithContext(ctx).Infof("MFA disabled for user: %s", userID.String())
+	return nil
+}
+
+// mfaChallenge returns the MFA challenge a login must answer, or nil if none is needed
+func (uc *AuthUsecase) mfaChallenge(ctx context.Context, user *User) (*LoginResponse, error) {
+	secret, err := uc.mfaQueryRepo.FindSecretByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	purpose := jwt.PurposeMFA
+	var enrollment *MFAEnrollment
+	if secret == nil || !secret.Enabled {
+		if !uc.config.MFA.RequireForAdmins {
+			return nil, nil
+		}
+		isAdmin, err := uc.hasRole(ctx, user, RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			return nil, nil
+		}
+		// Mandatory enrollment: hand out a fresh secret, VerifyMFA confirms it
+		purpose = jwt.PurposeMFAEnroll
+		enrollment, err = uc.startMFAEnrollment(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := jwt.GenerateChallengeToken(user.ID, tenant.OrDefault(user.TenantID), purpose, uc.keys, uc.config.MFA.ChallengeTTL)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to generate MFA token: %v", err)
+		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate MFA token")
+	}
+
+	return &LoginResponse{
+		ExpiresIn:     int64(uc.config.MFA.ChallengeTTL.Seconds()),
+		User:          user,
+		MFARequired:   true,
+		MFAToken:      token,
+		MFAEnrollment: enrollment,
+	}, nil
+}
+
+// startMFAEnrollment generates and stores a new pending TOTP secret
+func (uc *AuthUsecase) startMFAEnrollment(ctx context.Context, user *User) (*MFAEnrollment, error) {
+	raw, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.InternalServer("MFA_SECRET_ERROR", "failed to generate MFA secret")
+	}
+	encrypted, err := totp.EncryptSecret(raw, []byte(uc.config.MFA.EncryptionKey))
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to encrypt MFA secret: %v", err)
+		return nil, errors.InternalServer("MFA_SECRET_ERROR", "failed to generate MFA secret")
+	}
+
+	secret := &MFASecret{
+		UserID:          user.ID,
+		SecretEncrypted: encrypted,
+	}
+	secret.SetAuditFields(ctx, true)
+	if secret.CreatedBy == nil {
+		secret.CreatedBy = &user.ID
+		secret.UpdatedBy = &user.ID
+	}
+
+	if _, err := uc.mfaCommandRepo.SaveSecret(ctx, secret); err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:     raw,
+		OTPAuthURI: totp.URI(uc.config.MFA.Issuer, user.Email, raw),
+	}, nil
+}
+
+// confirmMFAEnrollment checks the first code of a pending enrollment, enables MFA
+// and returns freshly generated recovery codes
+func (uc *AuthUsecase) confirmMFAEnrollment(ctx context.Context, secret *MFASecret, code string) ([]string, error) {
+	step, err := uc.matchTOTP(ctx, secret, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, errors.InternalServer("MFA_RECOVERY_CODE_ERROR", "failed to generate recovery codes")
+	}
+	recoveryCodes := make([]*MFARecoveryCode, 0, len(codes))
+	for _, c := range codes {
+		recoveryCode := &MFARecoveryCode{
+			UserID:   secret.UserID,
+			CodeHash: uc.hashToken(c),
+		}
+		recoveryCode.SetAuditFields(ctx, true)
+		if recoveryCode.CreatedBy == nil {
+			recoveryCode.CreatedBy = &secret.UserID
+			recoveryCode.UpdatedBy = &secret.UserID
+		}
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+	}
+
+	if err := uc.mfaCommandRepo.EnableMFA(ctx, secret.UserID, step, recoveryCodes); err != nil {
+		return nil, err
+	}
+
+	uc.log.WithContext(ctx).Infof("MFA enabled for user: %s", secret.UserID.String())
+	return codes, nil
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code
+func (uc *AuthUsecase) verifySecondFactor(ctx context.Context, secret *MFASecret, code, recoveryCode string) error {
+	if code != "" {
+		return uc.verifyTOTP(ctx, secret, code)
+	}
+	if recoveryCode == "" {
+		return ErrMFAInvalidCode
+	}
+
+	used, err := uc.mfaCommandRepo.UseRecoveryCode(ctx, secret.UserID, uc.hashToken(totp.NormalizeRecoveryCode(recoveryCode)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrMFAInvalidCode
+	}
+	uc.log.WithContext(ctx).Warnf("MFA recovery code used by user: %s", secret.UserID.String())
+	return nil
+}
+
+// verifyTOTP checks a TOTP code and consumes its time step so it cannot be replayed
+func (uc *AuthUsecase) verifyTOTP(ctx context.Context, secret *MFASecret, code string) error {
+	step, err := uc.matchTOTP(ctx, secret, code)
+	if err != nil {
+		return err
+	}
+
+	ok, err := uc.mfaCommandRepo.UseStep(ctx, secret.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMFAInvalidCode
+	}
+	return nil
+}
+
+// matchTOTP returns the time step a code belongs to
+func (uc *AuthUsecase) matchTOTP(ctx context.Context, secret *MFASecret, code string) (int64, error) {
+	raw, err := totp.DecryptSecret(secret.SecretEncrypted, []byte(uc.config.MFA.EncryptionKey))
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to decrypt MFA secret of user %s: %v", secret.UserID.String(), err)
+		return 0, errors.InternalServer("MFA_SECRET_ERROR", "failed to read MFA secret")
+	}
+
+	step, ok := totp.Validate(raw, code, time.Now(), totpSkew)
+	if !ok || step <= secret.LastUsedStep {
+		return 0, ErrMFAInvalidCode
+	}
+	return step, nil
+}
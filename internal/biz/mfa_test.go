@@ -0,0 +1,167 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+	"github.com/go-kratos/kratos-layout/internal/pkg/totp"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	mfaTestPassword     = "c0rrect-h0rse-Battery"
+	mfaTestRecoveryCode = "7KQ2-M9XD-4PWA"
+)
+
+// mfaTest is an AuthUsecase with one MFA-enrolled user who has a single recovery code
+type mfaTest struct {
+	uc    *AuthUsecase
+	store *memStore
+	user  *User
+}
+
+func newMFATest(t *testing.T) *mfaTest {
+	t.Helper()
+	hash, err := password.Hash(mfaTestPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := newMemStore()
+	uc := NewAuthUsecase(newFakeAuthRepos(store), nil, nil, nil, NewAuthConfigFromConf(nil),
+		jwt.NewHMACKeySet([]byte("test-secret")), log.NewStdLogger(io.Discard))
+	now := time.Now()
+	user := store.addUser(&User{
+		Email:           "mfa@example.com",
+		Username:        "mfa",
+		PasswordHash:    hash,
+		Role:            RoleUser,
+		EmailVerifiedAt: &now,
+	})
+	store.mfa[user.ID] = &MFASecret{UserID: user.ID, Enabled: true}
+	store.recovery[uc.hashToken(totp.NormalizeRecoveryCode(mfaTestRecoveryCode))] = true
+	return &mfaTest{uc: uc, store: store, user: user}
+}
+
+func (mt *mfaTest) login(password string) (*LoginResponse, error) {
+	return mt.uc.Login(context.Background(), &LoginRequest{Email: mt.user.Email, Password: password, IP: "203.0.113.25"})
+}
+
+// challenge logs in with the right password and returns the MFA token
+func (mt *mfaTest) challenge(t *testing.T) string {
+	t.Helper()
+	resp, err := mt.login(mfaTestPassword)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if !resp.MFARequired || resp.AccessToken != "" {
+		t.Fatalf("Login issued tokens without the second factor")
+	}
+	return resp.MFAToken
+}
+
+func (mt *mfaTest) verify(mfaToken, recoveryCode string) (*LoginResponse, error) {
+	return mt.uc.VerifyMFA(context.Background(), &VerifyMFARequest{MFAToken: mfaToken, RecoveryCode: recoveryCode, IP: "203.0.113.25"})
+}
+
+func (mt *mfaTest) stored() *User {
+	return mt.store.findUser(func(u *User) bool { return u.ID == mt.user.ID })
+}
+
+func TestVerifyMFALocksAccountAfterMaxAttempts(t *testing.T) {
+	mt := newMFATest(t)
+	maxAttempts := mt.uc.config.Lockout.MaxAttempts
+	mfaToken := mt.challenge(t)
+
+	for i := 0; i < maxAttempts; i++ {
+		if _, err := mt.verify(mfaToken, "WRNG-CODE-0000"); !errors.Is(err, ErrMFAInvalidCode) {
+			t.Fatalf("attempt %d: err = %v, want MFA_INVALID_CODE", i+1, err)
+		}
+	}
+	if user := mt.stored(); !user.IsLocked() || user.FailedLoginAttempts != maxAttempts {
+		t.Fatalf("after %d wrong codes: locked = %v, attempts = %d", maxAttempts, user.IsLocked(), user.FailedLoginAttempts)
+	}
+
+	// Neither the right code nor the right password gets through the lock
+	if _, err := mt.verify(mfaToken, mfaTestRecoveryCode); kerrors.Reason(err) != "ACCOUNT_LOCKED" {
+		t.Fatalf("right code while locked: err = %v, want ACCOUNT_LOCKED", err)
+	}
+	if _, err := mt.login(mfaTestPassword); kerrors.Reason(err) != "ACCOUNT_LOCKED" {
+		t.Fatalf("login while locked: err = %v, want ACCOUNT_LOCKED", err)
+	}
+
+	// Once the lock has ended a new login works and clears the counter only after the second factor
+	mt.store.mu.Lock()
+	ended := time.Now()
+	mt.store.users[mt.user.ID].LockedUntil = &ended
+	mt.store.mu.Unlock()
+	mfaToken = mt.challenge(t)
+	if attempts := mt.stored().FailedLoginAttempts; attempts != maxAttempts {
+		t.Fatalf("counter reset by the password alone: attempts = %d", attempts)
+	}
+	resp, err := mt.verify(mfaToken, mfaTestRecoveryCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.AccessToken == "" {
+		t.Fatal("no tokens after the second factor")
+	}
+	if user := mt.stored(); user.FailedLoginAttempts != 0 || user.LockedUntil != nil {
+		t.Fatalf("after login: attempts = %d, locked until %v", user.FailedLoginAttempts, user.LockedUntil)
+	}
+}
+
+func TestVerifyMFACountsCodesWithWrongPasswords(t *testing.T) {
+	mt := newMFATest(t)
+	maxAttempts := mt.uc.config.Lockout.MaxAttempts
+
+	for i := 0; i < maxAttempts-1; i++ {
+		if _, err := mt.login("wrong-password"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("err = %v, want INVALID_CREDENTIALS", err)
+		}
+	}
+	mfaToken := mt.challenge(t)
+
+	// The right password does not buy a fresh set of guesses
+	if _, err := mt.verify(mfaToken, "WRNG-CODE-0000"); !errors.Is(err, ErrMFAInvalidCode) {
+		t.Fatalf("err = %v, want MFA_INVALID_CODE", err)
+	}
+	if !mt.stored().IsLocked() {
+		t.Fatal("account not locked after one wrong code on top of the wrong passwords")
+	}
+}
+
+func TestLockedSince(t *testing.T) {
+	issued := time.Now().Add(-20 * time.Minute).Truncate(time.Second) // JWT times have second precision
+	at := func(d time.Duration) *time.Time {
+		until := issued.Add(d)
+		return &until
+	}
+
+	tests := []struct {
+		name        string
+		lockedUntil *time.Time
+		want        bool
+	}{
+		{"never locked", nil, false},
+		{"lock ended before the challenge", at(-time.Minute), false},
+		{"lock ended in the second the challenge was issued", at(900 * time.Millisecond), false},
+		// The challenge must not outlive a lock its wrong codes caused
+		{"locked after the challenge, lock ended", at(15 * time.Minute), true},
+		{"locked after the challenge, still locked", at(time.Hour), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lockedSince(&User{LockedUntil: tt.lockedUntil}, issued); got != tt.want {
+				t.Fatalf("lockedSince = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
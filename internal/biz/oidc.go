@@ -0,0 +1,437 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/pkg/oidc"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrOIDCProviderNotFound   = errors.NotFound("OIDC_PROVIDER_NOT_FOUND", "identity provider not found")
+	ErrOIDCStateInvalid       = errors.BadRequest("OIDC_STATE_INVALID", "login state is invalid or expired")
+	ErrOIDCLoginFailed        = errors.Unauthorized("OIDC_LOGIN_FAILED", "login with the identity provider failed")
+	ErrOIDCAccountExists      = errors.Conflict("OIDC_ACCOUNT_EXISTS", "an account with this email already exists")
+	ErrOIDCUserNotProvisioned = errors.Forbidden("OIDC_USER_NOT_PROVISIONED", "no account is linked to this identity")
+)
+
+// OIDCLoginState is the server side of one login at an identity provider:
+// it binds the callback to the login start (state), the ID token to the login
+// (nonce) and the code exchange to the login (PKCE verifier)
+type OIDCLoginState struct {
+	BaseEntity
+
+	Provider     string     `gorm:"type:varchar(100);not null" json:"provider"`
+	StateHash    string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // HMAC-SHA256 of the state
+	Nonce        string     `gorm:"type:varchar(100);not null" json:"-"`
+	CodeVerifier string     `gorm:"type:varchar(100);not null" json:"-"`
+	ExpiresAt    time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt       *time.Time `gorm:"type:timestamp" json:"used_at,omitempty"`
+	IPAddress    string     `gorm:"type:varchar(45)" json:"ip_address"`
+}
+
+// UserIdentity links a user to an account at an identity provider
+type UserIdentity struct {
+	BaseEntity
+
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Provider    string     `gorm:"type:varchar(100);not null;index:idx_user_identities_tenant_provider_subject" json:"provider"`
+	Subject     string     `gorm:"type:varchar(255);not null;index:idx_user_identities_tenant_provider_subject" json:"subject"` // "sub" claim, unique per tenant and provider
+	Email       string     `gorm:"type:varchar(255)" json:"email"`                                                              // Email at the provider
+	LastLoginAt *time.Time `gorm:"type:timestamp" json:"last_login_at,omitempty"`
+}
+
+// OIDCConfig configures external OpenID Connect login
+type OIDCConfig struct {
+	StateTTL  time.Duration
+	Providers []OIDCProviderConfig
+}
+
+// OIDCProviderConfig configures one identity provider
+type OIDCProviderConfig struct {
+	Name          string
+	Client        oidc.Config
+	RoleClaim     string
+	RoleMappings  []OIDCRoleMapping // First match wins
+	DefaultRole   string
+	AutoProvision bool
+	LinkByEmail   bool
+}
+
+// OIDCRoleMapping maps a value of the role claim to a role
+type OIDCRoleMapping struct {
+	ClaimValue string
+	Role       string
+}
+
+// OIDCCallbackRequest completes a login at an identity provider
+type OIDCCallbackRequest struct {
+	Provider  string
+	Code      string
+	State     string
+	Error     string // "error" parameter sent by the provider instead of a code
+	IP        string
+	UserAgent string
+}
+
+// OIDCCommandRepo for write operations
+type OIDCCommandRepo interface {
+	SaveLoginState(context.Context, *OIDCLoginState) (*OIDCLoginState, error)
+	// UseLoginState atomically marks an unused, unexpired state (by hash) as used.
+	// Returns nil if no such state exists.
+	UseLoginState(context.Context, string) (*OIDCLoginState, error)
+	SaveIdentity(context.Context, *UserIdentity) (*UserIdentity, error)
+	UpdateIdentityLastLogin(context.Context, uuid.UUID) error
+}
+
+// OIDCQueryRepo for read operations
+type OIDCQueryRepo interface {
+	// FindIdentity returns the identity of a provider subject, nil if not linked
+	FindIdentity(ctx context.Context, provider, subject string) (*UserIdentity, error)
+}
+
+// oidcProvider is a configured identity provider
+type oidcProvider struct {
+	config OIDCProviderConfig
+	client *oidc.Provider
+}
+
+func newOIDCProviders(cfg OIDCConfig) map[string]*oidcProvider {
+	providers := make(map[string]*oidcProvider, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		providers[p.Name] = &oidcProvider{config: p, client: oidc.NewProvider(p.Client, nil)}
+	}
+	return providers
+}
+
+func newOIDCConfigFromConf(c *conf.Auth_OIDC) OIDCConfig {
+	cfg := OIDCConfig{StateTTL: 10 * time.Minute}
+	if c == nil {
+		return cfg
+	}
+	if c.StateTtl != nil && c.StateTtl.AsDuration() > 0 {
+		cfg.StateTTL = c.StateTtl.AsDuration()
+	}
+	for _, p := range c.Providers {
+		provider := OIDCProviderConfig{
+			Name: p.Name,
+			Client: oidc.Config{
+				Issuer:       p.Issuer,
+				ClientID:     p.ClientId,
+				ClientSecret: p.ClientSecret,
+				RedirectURL:  p.RedirectUrl,
+				Scopes:       p.Scopes,
+			},
+			RoleClaim:     p.RoleClaim,
+			DefaultRole:   p.DefaultRole,
+			AutoProvision: p.AutoProvision,
+			LinkByEmail:   p.LinkByEmail,
+		}
+		if len(provider.Client.Scopes) == 0 {
+			provider.Client.Scopes = []string{"openid", "email", "profile"}
+		}
+		if provider.DefaultRole == "" {
+			provider.DefaultRole = "user"
+		}
+		for _, m := range p.RoleMappings {
+			provider.RoleMappings = append(provider.RoleMappings, OIDCRoleMapping{ClaimValue: m.ClaimValue, Role: m.Role})
+		}
+		cfg.Providers = append(cfg.Providers, provider)
+	}
+	return cfg
+}
+
+// StartOIDCLogin returns the provider URL that starts a login
+func (uc *AuthUsecase) StartOIDCLogin(ctx context.Context, providerName, ip string) (string, error) {
+	provider, ok := uc.oidcProviders[providerName]
+	if !ok {
+		return "", ErrOIDCProviderNotFound
+	}
+
+	state, err1 := oidc.GenerateCodeVerifier()
+	nonce, err2 := oidc.GenerateCodeVerifier()
+	verifier, err3 := oidc.GenerateCodeVerifier()
+	if err1 != nil || err2 != nil || err3 != nil {
+		return "", errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate login state")
+	}
+
+	loginState := &OIDCLoginState{
+		Provider:     providerName,
+		StateHash:    uc.hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(uc.config.OIDC.StateTTL),
+		IPAddress:    ip,
+	}
+	if _, err := uc.oidcCommandRepo.SaveLoginState(ctx, loginState); err != nil {
+		return "", errors.InternalServer("TOKEN_SAVE_ERROR", "failed to save login state")
+	}
+
+	authURL, err := provider.client.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("OIDC discovery failed for %s: %v", providerName, err)
+		return "", errors.ServiceUnavailable("OIDC_PROVIDER_UNAVAILABLE", "identity provider is unavailable")
+	}
+	return authURL, nil
+}
+
+// CompleteOIDCLogin verifies the provider callback, finds, links or provisions
+// the user and signs them in like Login does
+func (uc *AuthUsecase) CompleteOIDCLogin(ctx context.Context, req *OIDCCallbackRequest) (*LoginResponse, error) {
+	provider, ok := uc.oidcProviders[req.Provider]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	// The provider redirect carries no tenant, the state names the tenant the login started in
+	loginState, err := uc.oidcCommandRepo.UseLoginState(tenant.WithAllTenants(ctx), uc.hashToken(req.State))
+	if err != nil {
+		return nil, err
+	}
+	if loginState == nil || loginState.Provider != req.Provider {
+		return nil, ErrOIDCStateInvalid
+	}
+	ctx = inRecordTenant(ctx, &loginState.BaseEntity)
+
+	if req.Error != "" || req.Code == "" {
+		uc.log.WithContext(ctx).Warnf("OIDC login at %s returned error: %s", req.Provider, req.Error)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	rawIDToken, err := provider.client.Exchange(ctx, req.Code, loginState.CodeVerifier)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("OIDC code exchange at %s failed: %v", req.Provider, err)
+		return nil, ErrOIDCLoginFailed
+	}
+	claims, err := provider.client.VerifyIDToken(ctx, rawIDToken, loginState.Nonce)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("OIDC ID token from %s rejected: %v", req.Provider, err)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	user, err := uc.resolveOIDCUser(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, errors.Forbidden("USER_INACTIVE", "user account is inactive")
+	}
+	if err := uc.checkEmailVerified(user); err != nil {
+		return nil, err
+	}
+
+	challenge, err := uc.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		uc.log.WithContext(ctx).Infof("MFA challenge issued: %s", user.Email)
+		return challenge, nil
+	}
+
+	uc.log.WithContext(ctx).Infof("OIDC login via %s: %s", req.Provider, user.Email)
+	return uc.issueSession(ctx, user, LoginMethodOIDC, req.IP, req.UserAgent)
+}
+
+// resolveOIDCUser returns the user linked to the provider subject, linking an
+// existing user by verified email or provisioning a new one when allowed
+func (uc *AuthUsecase) resolveOIDCUser(ctx context.Context, provider *oidcProvider, claims *oidc.Claims) (*User, error) {
+	cfg := provider.config
+	role, roleMapped := mapOIDCRole(cfg, claims.Raw)
+
+	identity, err := uc.oidcQueryRepo.FindIdentity(ctx, cfg.Name, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *User
+	if identity != nil {
+		user, err = uc.userQueryRepo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.oidcCommandRepo.UpdateIdentityLastLogin(ctx, identity.ID); err != nil {
+			uc.log.WithContext(ctx).Warnf("Failed to update identity last login: %v", err)
+		}
+	} else {
+		if claims.Email == "" {
+			return nil, ErrOIDCUserNotProvisioned
+		}
+
+		user, err = uc.userQueryRepo.FindByEmail(ctx, claims.Email)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case user != nil && (!cfg.LinkByEmail || !claims.EmailVerified):
+			// An unverified email at the provider must never take over an account
+			return nil, ErrOIDCAccountExists
+		case user == nil && !cfg.AutoProvision:
+			return nil, ErrOIDCUserNotProvisioned
+		case user == nil:
+			if !roleMapped {
+				role = cfg.DefaultRole
+			}
+			if user, err = uc.provisionOIDCUser(ctx, claims, role); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := uc.linkOIDCIdentity(ctx, cfg.Name, user, claims); err != nil {
+			return nil, err
+		}
+		uc.log.WithContext(ctx).Infof("Linked %s identity %s to user %s", cfg.Name, claims.Subject, user.Email)
+	}
+
+	if !user.IsEmailVerified() && claims.EmailVerified && strings.EqualFold(user.Email, claims.Email) {
+		if err := uc.userCommandRepo.MarkEmailVerified(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	// The provider is the source of truth for mapped roles
+	if roleMapped && user.Role != role {
+		uc.log.WithContext(ctx).Infof("Role of %s changed from %s to %s by %s", user.Email, user.Role, role, cfg.Name)
+		user.Role = role
+		if user, err = uc.userCommandRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// provisionOIDCUser creates a user for a provider identity. The user has no
+// usable password until they reset it.
+func (uc *AuthUsecase) provisionOIDCUser(ctx context.Context, claims *oidc.Claims, role string) (*User, error) {
+	unusable, err := generateOneTimeToken()
+	if err != nil {
+		return nil, errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+	}
+	passwordHash, err := password.Hash(unusable)
+	if err != nil {
+		return nil, errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+	}
+
+	username, err := uc.availableUsername(ctx, claims.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.Must(uuid.NewV7())
+	now := time.Now()
+	user := &User{
+		Email:             claims.Email,
+		Username:          username,
+		PasswordHash:      passwordHash,
+		PasswordChangedAt: &now,
+		FullName:          claims.Name,
+		Role:              role,
+	}
+	user.ID = id
+	// Provisioned users create themselves
+	user.CreatedBy = &id
+	user.UpdatedBy = &id
+	if claims.EmailVerified {
+		user.EmailVerifiedAt = &now
+	}
+
+	createdUser, err := uc.userCommandRepo.Save(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("Provisioned user from identity provider: %s", createdUser.Email)
+	return createdUser, nil
+}
+
+func (uc *AuthUsecase) linkOIDCIdentity(ctx context.Context, provider string, user *User, claims *oidc.Claims) error {
+	now := time.Now()
+	identity := &UserIdentity{
+		UserID:      user.ID,
+		Provider:    provider,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		LastLoginAt: &now,
+	}
+	identity.CreatedBy = &user.ID
+	identity.UpdatedBy = &user.ID
+
+	_, err := uc.oidcCommandRepo.SaveIdentity(ctx, identity)
+	return err
+}
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// availableUsername derives an unused username from the local part of an email
+func (uc *AuthUsecase) availableUsername(ctx context.Context, email string) (string, error) {
+	base := strings.ToLower(email)
+	if i := strings.Index(base, "@"); i >= 0 {
+		base = base[:i]
+	}
+	base = usernameInvalidChars.ReplaceAllString(base, "")
+	if len(base) < 3 {
+		base = "user"
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	username := base
+	for i := 0; i < 5; i++ {
+		existing, err := uc.userQueryRepo.FindByUsername(ctx, username)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return username, nil
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(100000))
+		if err != nil {
+			return "", err
+		}
+		username = fmt.Sprintf("%s%05d", base, n.Int64())
+	}
+	return "", ErrUserAlreadyExists
+}
+
+// mapOIDCRole returns the role of the first mapping matched by the role claim.
+// The claim may be a string or a list of strings (e.g. groups).
+func mapOIDCRole(cfg OIDCProviderConfig, raw map[string]interface{}) (string, bool) {
+	if cfg.RoleClaim == "" {
+		return "", false
+	}
+
+	var values []string
+	switch v := raw[cfg.RoleClaim].(type) {
+	case string:
+		values = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	for _, mapping := range cfg.RoleMappings {
+		for _, value := range values {
+			if value == mapping.ClaimValue {
+				return mapping.Role, true
+			}
+		}
+	}
+	return "", false
+}
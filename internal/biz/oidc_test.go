@@ -0,0 +1,305 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/oidc"
+	"github.com/go-kratos/kratos-layout/internal/pkg/oidc/oidctest"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const testOIDCProvider = "corporate"
+
+// oidcTest is an AuthUsecase with in-memory repositories and one provider served by a stub IdP
+type oidcTest struct {
+	uc    *AuthUsecase
+	idp   *oidctest.IdP
+	store *memStore
+	keys  *jwt.KeySet
+}
+
+func newOIDCTest(t *testing.T, configure func(*OIDCProviderConfig)) *oidcTest {
+	t.Helper()
+	idp, server, err := oidctest.NewServer("backend-service", "stub-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	provider := OIDCProviderConfig{
+		Name: testOIDCProvider,
+		Client: oidc.Config{
+			Issuer:       idp.Issuer,
+			ClientID:     idp.ClientID,
+			ClientSecret: idp.ClientSecret,
+			RedirectURL:  "http://localhost:8000/api/v1/auth/oidc/corporate/callback",
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		DefaultRole:   RoleUser,
+		AutoProvision: true,
+		LinkByEmail:   true,
+	}
+	if configure != nil {
+		configure(&provider)
+	}
+
+	config := NewAuthConfigFromConf(nil)
+	config.OIDC.Providers = []OIDCProviderConfig{provider}
+	store := newMemStore()
+	keys := jwt.NewHMACKeySet([]byte("test-secret"))
+	uc := NewAuthUsecase(newFakeAuthRepos(store), nil, nil, nil, config, keys, log.NewStdLogger(io.Discard))
+	return &oidcTest{uc: uc, idp: idp, store: store, keys: keys}
+}
+
+// authorize starts a login and lets the stub IdP sign in its user, returning the
+// callback request. tamper may change the provider URL before it is followed.
+func (ot *oidcTest) authorize(t *testing.T, tamper func(url.Values)) *OIDCCallbackRequest {
+	t.Helper()
+	authURL, err := ot.uc.StartOIDCLogin(context.Background(), testOIDCProvider, "203.0.113.25")
+	if err != nil {
+		t.Fatalf("StartOIDCLogin: %v", err)
+	}
+	if tamper != nil {
+		u, _ := url.Parse(authURL)
+		q := u.Query()
+		tamper(q)
+		u.RawQuery = q.Encode()
+		authURL = u.String()
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	callback, err := resp.Location()
+	if err != nil {
+		t.Fatalf("IdP did not redirect (%s): %v", resp.Status, err)
+	}
+	return &OIDCCallbackRequest{
+		Provider:  testOIDCProvider,
+		Code:      callback.Query().Get("code"),
+		State:     callback.Query().Get("state"),
+		IP:        "203.0.113.25",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/131.0.0.0 Safari/537.36",
+	}
+}
+
+func (ot *oidcTest) login(t *testing.T) (*LoginResponse, error) {
+	t.Helper()
+	return ot.uc.CompleteOIDCLogin(context.Background(), ot.authorize(t, nil))
+}
+
+func TestCompleteOIDCLoginRejectsBadState(t *testing.T) {
+	ot := newOIDCTest(t, nil)
+
+	tests := []struct {
+		name   string
+		modify func(t *testing.T, req *OIDCCallbackRequest)
+	}{
+		{"forged state", func(t *testing.T, req *OIDCCallbackRequest) { req.State = "forged" }},
+		{"replayed state", func(t *testing.T, req *OIDCCallbackRequest) {
+			if _, err := ot.uc.CompleteOIDCLogin(context.Background(), req); err != nil {
+				t.Fatalf("first callback: %v", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ot.authorize(t, nil)
+			tt.modify(t, req)
+			_, err := ot.uc.CompleteOIDCLogin(context.Background(), req)
+			if !errors.Is(err, ErrOIDCStateInvalid) {
+				t.Fatalf("err = %v, want OIDC_STATE_INVALID", err)
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginRejectsNonceOfAnotherLogin(t *testing.T) {
+	ot := newOIDCTest(t, nil)
+
+	// The ID token carries a nonce the login did not start with
+	req := ot.authorize(t, func(q url.Values) { q.Set("nonce", "nonce-of-another-login") })
+	if _, err := ot.uc.CompleteOIDCLogin(context.Background(), req); !errors.Is(err, ErrOIDCLoginFailed) {
+		t.Fatalf("err = %v, want OIDC_LOGIN_FAILED", err)
+	}
+	if len(ot.store.users) != 0 {
+		t.Fatalf("a user was provisioned from a rejected ID token")
+	}
+}
+
+func TestCompleteOIDCLoginRejectsCodeOfAnotherPKCEChallenge(t *testing.T) {
+	ot := newOIDCTest(t, nil)
+
+	// The code was issued for another PKCE challenge, e.g. a code injected from another login
+	req := ot.authorize(t, func(q url.Values) { q.Set("code_challenge", "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM") })
+	if _, err := ot.uc.CompleteOIDCLogin(context.Background(), req); !errors.Is(err, ErrOIDCLoginFailed) {
+		t.Fatalf("err = %v, want OIDC_LOGIN_FAILED", err)
+	}
+}
+
+func TestCompleteOIDCLoginLinksByEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		linkByEmail   bool
+		emailVerified bool
+		wantErr       error
+	}{
+		{"verified email is linked", true, true, nil},
+		{"unverified email is not linked", true, false, ErrOIDCAccountExists},
+		{"linking disabled", false, true, ErrOIDCAccountExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ot := newOIDCTest(t, func(p *OIDCProviderConfig) { p.LinkByEmail = tt.linkByEmail })
+			existing := ot.store.addUser(&User{Email: "staff@example.com", Username: "staff", Role: RoleUser})
+			ot.idp.SetUser(map[string]interface{}{
+				"sub":            "staff-at-idp",
+				"email":          "staff@example.com",
+				"email_verified": tt.emailVerified,
+			})
+
+			resp, err := ot.login(t)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr != nil) != (err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(ot.store.identities) != 0 {
+					t.Fatalf("identity was linked to %s", existing.Email)
+				}
+				return
+			}
+			if resp.User.ID != existing.ID || resp.AccessToken == "" {
+				t.Fatalf("signed in %v, want existing user %s with tokens", resp.User.ID, existing.ID)
+			}
+			if len(ot.store.identities) != 1 || ot.store.identities[0].UserID != existing.ID {
+				t.Fatalf("identities = %v, want one linked to the existing user", ot.store.identities)
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginAutoProvisioning(t *testing.T) {
+	tests := []struct {
+		name          string
+		autoProvision bool
+		wantErr       error
+	}{
+		{"enabled", true, nil},
+		{"disabled", false, ErrOIDCUserNotProvisioned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ot := newOIDCTest(t, func(p *OIDCProviderConfig) { p.AutoProvision = tt.autoProvision })
+			ot.idp.SetUser(map[string]interface{}{
+				"sub":            "new-at-idp",
+				"email":          "new.staff@example.com",
+				"email_verified": true,
+				"name":           "New Staff",
+			})
+
+			resp, err := ot.login(t)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr != nil) != (err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(ot.store.users) != 0 {
+					t.Fatalf("user was provisioned")
+				}
+				return
+			}
+			user := resp.User
+			if user.Email != "new.staff@example.com" || user.Username != "new.staff" || user.FullName != "New Staff" ||
+				!user.IsEmailVerified() || user.Role != RoleUser {
+				t.Fatalf("provisioned user = %+v", user)
+			}
+
+			// The next login finds the user through the linked identity
+			again, err := ot.login(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again.User.ID != user.ID || len(ot.store.users) != 1 {
+				t.Fatalf("second login signed in %s, want %s", again.User.ID, user.ID)
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginRoleMapping(t *testing.T) {
+	ot := newOIDCTest(t, func(p *OIDCProviderConfig) {
+		p.RoleClaim = "groups"
+		p.RoleMappings = []OIDCRoleMapping{
+			{ClaimValue: "admins", Role: RoleAdmin},
+			{ClaimValue: "staff", Role: "moderator"},
+		}
+	})
+
+	tests := []struct {
+		name     string
+		groups   interface{}
+		wantRole string
+	}{
+		{"first matching mapping wins", []interface{}{"staff", "admins"}, RoleAdmin},
+		{"mapped role replaces the current one", []interface{}{"staff"}, "moderator"},
+		{"string claim", "admins", RoleAdmin},
+		// An unmapped claim keeps the role: the provider only owns mapped roles
+		{"no mapping keeps the role", []interface{}{"everyone"}, RoleAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ot.idp.SetUser(map[string]interface{}{
+				"sub":            "mapped-at-idp",
+				"email":          "mapped@example.com",
+				"email_verified": true,
+				"groups":         tt.groups,
+			})
+
+			resp, err := ot.login(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.User.Role != tt.wantRole {
+				t.Fatalf("user role = %s, want %s", resp.User.Role, tt.wantRole)
+			}
+			claims, err := jwt.ValidateToken(resp.AccessToken, ot.keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.Role != tt.wantRole {
+				t.Fatalf("token role = %s, want %s", claims.Role, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginProvisionsUnmappedUserWithDefaultRole(t *testing.T) {
+	ot := newOIDCTest(t, func(p *OIDCProviderConfig) {
+		p.RoleClaim = "groups"
+		p.RoleMappings = []OIDCRoleMapping{{ClaimValue: "admins", Role: RoleAdmin}}
+		p.DefaultRole = "moderator"
+	})
+	ot.idp.SetUser(map[string]interface{}{
+		"sub":            "unmapped-at-idp",
+		"email":          "unmapped@example.com",
+		"email_verified": true,
+		"groups":         []interface{}{"everyone"},
+	})
+
+	resp, err := ot.login(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.User.Role != "moderator" {
+		t.Fatalf("role = %s, want the default role", resp.User.Role)
+	}
+}
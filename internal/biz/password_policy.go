@@ -0,0 +1,143 @@
+package biz
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrPasswordReused = errors.BadRequest("PASSWORD_REUSED", "the new password was used recently, choose a different one")
+)
+
+// PasswordHistory keeps a previous password hash of a user. Rows are written
+// by UserCommandRepo.UpdatePassword before the password is replaced.
+type PasswordHistory struct {
+	BaseEntity
+
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	PasswordHash string    `gorm:"type:varchar(255);not null" json:"-"`
+}
+
+// TableName keeps the singular table name of the compliance spec
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
+
+// PasswordPolicyConfig configures password reuse and expiry
+type PasswordPolicyConfig struct {
+	HistorySize    int                      // The new password must differ from the last HistorySize passwords, 0 = off
+	MaxAge         map[string]time.Duration // Password lifetime by role, missing role = never expires
+	ChangeTokenTTL time.Duration            // Lifetime of the limited token issued for an expired password
+}
+
+func newPasswordPolicyConfigFromConf(c *conf.Auth_PasswordPolicy) PasswordPolicyConfig {
+	cfg := PasswordPolicyConfig{
+		MaxAge:         map[string]time.Duration{},
+		ChangeTokenTTL: 10 * time.Minute,
+	}
+	if c == nil {
+		return cfg
+	}
+	if c.HistorySize > 0 {
+		cfg.HistorySize = int(c.HistorySize)
+	}
+	for role, maxAge := range c.MaxAge {
+		if maxAge != nil && maxAge.AsDuration() > 0 {
+			cfg.MaxAge[role] = maxAge.AsDuration()
+		}
+	}
+	if c.ChangeTokenTtl != nil && c.ChangeTokenTtl.AsDuration() > 0 {
+		cfg.ChangeTokenTTL = c.ChangeTokenTtl.AsDuration()
+	}
+	return cfg
+}
+
+// checkPasswordReuse rejects a new password matching the current password or
+// one of the previous HistorySize-1 passwords of the user
+func checkPasswordReuse(ctx context.Context, queryRepo UserQueryRepo, policy PasswordPolicyConfig, user *User, newPassword string) error {
+	if policy.HistorySize <= 0 {
+		return nil
+	}
+
+	hashes := []string{user.PasswordHash}
+	if policy.HistorySize > 1 {
+		previous, err := queryRepo.ListPasswordHistory(ctx, user.ID, policy.HistorySize-1)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, previous...)
+	}
+
+	for _, hash := range hashes {
+		if ok, _ := password.Verify(newPassword, hash); ok {
+			return ErrPasswordReused.WithMetadata(map[string]string{
+				"history_size": strconv.Itoa(policy.HistorySize),
+			})
+		}
+	}
+	return nil
+}
+
+// isPasswordExpired reports whether the password of the user is older than the
+// maximum age of any of the roles. The strictest role wins.
+func (p PasswordPolicyConfig) isPasswordExpired(user *User, roles []string) bool {
+	var maxAge time.Duration
+	for _, role := range roles {
+		if age, ok := p.MaxAge[role]; ok && (maxAge == 0 || age < maxAge) {
+			maxAge = age
+		}
+	}
+	if maxAge == 0 {
+		return false
+	}
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return time.Since(changedAt) > maxAge
+}
+
+// passwordExpired checks the password age against the primary role of the user and
+// every additional role they hold
+func (uc *AuthUsecase) passwordExpired(ctx context.Context, user *User) (bool, error) {
+	if len(uc.config.PasswordPolicy.MaxAge) == 0 {
+		return false, nil
+	}
+	roles, err := uc.roleQueryRepo.ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	names := []string{user.Role}
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return uc.config.PasswordPolicy.isPasswordExpired(user, names), nil
+}
+
+// passwordChangeChallenge returns the "must change password" login response:
+// only a limited token that is accepted by ChangeMyPassword
+func (uc *AuthUsecase) passwordChangeChallenge(ctx context.Context, user *User) (*LoginResponse, error) {
+	token, err := jwt.GenerateChallengeToken(user.ID, tenant.OrDefault(user.TenantID), jwt.PurposePasswordChange, uc.keys, uc.config.PasswordPolicy.ChangeTokenTTL)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to generate password change token: %v", err)
+		return nil, errors.InternalServer("TOKEN_GENERATION_ERROR", "failed to generate password change token")
+	}
+
+	uc.log.WithContext(ctx).Infof("Password expired, change required: %s", user.Email)
+	return &LoginResponse{
+		ExpiresIn:              int64(uc.config.PasswordPolicy.ChangeTokenTTL.Seconds()),
+		TokenType:              "Bearer",
+		User:                   user,
+		PasswordChangeRequired: true,
+		PasswordChangeToken:    token,
+	}, nil
+}
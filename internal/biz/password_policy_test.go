@@ -0,0 +1,69 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestLoginPasswordExpiryCoversEveryRole(t *testing.T) {
+	const secret = "c0rrect-h0rse-Battery"
+	const day = 24 * time.Hour
+	hash, err := password.Hash(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		primary     string
+		extra       []string
+		age         time.Duration
+		wantExpired bool
+	}{
+		{"role without max age", RoleUser, nil, 400 * day, false},
+		{"primary role expired", "auditor", nil, 40 * day, true},
+		{"primary role not expired", "auditor", nil, 20 * day, false},
+		// An additional role cannot be used to escape its stricter policy
+		{"additional role expired", RoleUser, []string{"auditor"}, 40 * day, true},
+		{"strictest role wins", RoleAdmin, []string{"auditor"}, 40 * day, true},
+		{"no role expired", RoleAdmin, []string{"auditor"}, 20 * day, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewAuthConfigFromConf(nil)
+			config.PasswordPolicy.MaxAge = map[string]time.Duration{RoleAdmin: 90 * day, "auditor": 30 * day}
+			store := newMemStore()
+			uc := NewAuthUsecase(newFakeAuthRepos(store), nil, nil, nil, config,
+				jwt.NewHMACKeySet([]byte("test-secret")), log.NewStdLogger(io.Discard))
+
+			changedAt := time.Now().Add(-tt.age)
+			user := store.addUser(&User{
+				Email:             "expiry@example.com",
+				Username:          "expiry",
+				PasswordHash:      hash,
+				Role:              tt.primary,
+				EmailVerifiedAt:   &changedAt,
+				PasswordChangedAt: &changedAt,
+			})
+			store.roles[user.ID] = tt.extra
+
+			resp, err := uc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: secret})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.PasswordChangeRequired != tt.wantExpired {
+				t.Fatalf("password change required = %v, want %v", resp.PasswordChangeRequired, tt.wantExpired)
+			}
+			if tt.wantExpired && resp.AccessToken != "" {
+				t.Fatal("tokens issued for an expired password")
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrResetTokenInvalid = errors.BadRequest("RESET_TOKEN_INVALID", "reset token is invalid or expired")
+)
+
+// PasswordResetToken is a single-use token sent by email to reset a forgotten password
+type PasswordResetToken struct {
+	BaseEntity
+
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // HMAC-SHA256 of the token
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"type:timestamp" json:"used_at,omitempty"`
+	IPAddress string     `gorm:"type:varchar(45)" json:"ip_address"` // IP that requested the reset
+}
+
+// PasswordResetConfig configures the self-service password reset
+type PasswordResetConfig struct {
+	TokenTTL time.Duration
+	URL      string // "{token}" is replaced by the reset token
+}
+
+// PasswordResetCommandRepo for write operations
+type PasswordResetCommandRepo interface {
+	SaveResetToken(context.Context, *PasswordResetToken) (*PasswordResetToken, error)
+	// UseResetToken atomically marks an unused, unexpired token (by hash) as used.
+	// Returns nil if no such token exists.
+	UseResetToken(context.Context, string) (*PasswordResetToken, error)
+	// FindValidResetToken returns an unused, unexpired token (by hash) without using it,
+	// or nil if no such token exists
+	FindValidResetToken(context.Context, string) (*PasswordResetToken, error)
+	// InvalidateUserResetTokens marks every unused token of a user as used
+	InvalidateUserResetTokens(context.Context, uuid.UUID) error
+}
+
+// passwordResetIssueTimeout bounds issuing and mailing a reset link after the request returned
+const passwordResetIssueTimeout = 30 * time.Second
+
+// RequestPasswordReset emails a reset link to the user with this email.
+// It succeeds whether or not the email is registered so accounts cannot be enumerated:
+// the link is issued and mailed in the background, so the response takes as long
+// for an unknown account as for a registered one.
+func (uc *AuthUsecase) RequestPasswordReset(ctx context.Context, email, ip string) error {
+	uc.log.WithContext(ctx).Infof("Password reset requested: %s", email)
+
+	user, err := uc.userQueryRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive() {
+		uc.log.WithContext(ctx).Infof("Password reset for unknown or inactive account: %s", email)
+		return nil
+	}
+
+	issueCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetIssueTimeout)
+	go func() {
+		defer cancel()
+		uc.sendPasswordReset(issueCtx, user, ip)
+	}()
+	return nil
+}
+
+// sendPasswordReset replaces the user's reset link with a new one and mails it.
+// Failures are only logged, the caller must not learn about them.
+func (uc *AuthUsecase) sendPasswordReset(ctx context.Context, user *User, ip string) {
+	// Only the latest link works
+	if err := uc.resetRepo.InvalidateUserResetTokens(ctx, user.ID); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to invalidate reset tokens of %s: %v", user.Email, err)
+		return
+	}
+
+	token, err := generateOneTimeToken()
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to generate reset token for %s: %v", user.Email, err)
+		return
+	}
+
+	resetToken := &PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: uc.hashToken(token),
+		ExpiresAt: time.Now().Add(uc.config.PasswordReset.TokenTTL),
+		IPAddress: ip,
+	}
+	resetToken.SetAuditFields(ctx, true)
+	if resetToken.CreatedBy == nil {
+		resetToken.CreatedBy = &user.ID
+		resetToken.UpdatedBy = &user.ID
+	}
+
+	if _, err := uc.resetRepo.SaveResetToken(ctx, resetToken); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to save reset token of %s: %v", user.Email, err)
+		return
+	}
+
+	msg := &MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nWe received a request to reset your password. Use the link below within %s:\n\n%s\n\n"+
+				"If you did not request a password reset, you can ignore this email.\n",
+			user.GetDisplayName(), uc.config.PasswordReset.TokenTTL, uc.resetLink(token),
+		),
+	}
+	if err := uc.mailer.Send(ctx, msg); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to send password reset email to %s: %v", user.Email, err)
+	}
+}
+
+// ConfirmPasswordReset sets a new password with a reset token and signs the user out everywhere.
+// The new password must already be validated.
+func (uc *AuthUsecase) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	if err := checkPasswordBreached(uc.breachedPasswords, newPassword); err != nil {
+		return err
+	}
+
+	tokenHash := uc.hashToken(token)
+
+	// Reject a reused password before the token is used up, so the link can be tried again.
+	// The link carries no tenant, the token names it.
+	pending, err := uc.resetRepo.FindValidResetToken(tenant.WithAllTenants(ctx), tokenHash)
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return ErrResetTokenInvalid
+	}
+	ctx = inRecordTenant(ctx, &pending.BaseEntity)
+	user, err := uc.userQueryRepo.FindByID(ctx, pending.UserID)
+	if err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(ctx, uc.userQueryRepo, uc.config.PasswordPolicy, user, newPassword); err != nil {
+		return err
+	}
+
+	resetToken, err := uc.resetRepo.UseResetToken(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if resetToken == nil {
+		return ErrResetTokenInvalid
+	}
+
+	passwordHash, err := password.Hash(newPassword)
+	if err != nil {
+		return errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+	}
+
+	if err := uc.userCommandRepo.UpdatePassword(ctx, resetToken.UserID, passwordHash); err != nil {
+		return err
+	}
+
+	// Whoever knew the old password must not stay signed in
+	if err := uc.RevokeAllTokens(ctx, resetToken.UserID); err != nil {
+		return err
+	}
+
+	uc.log.WithContext(ctx).Infof("Password reset completed for user: %s", resetToken.UserID.String())
+	return nil
+}
+
+// resetLink renders the reset URL for a token
+func (uc *AuthUsecase) resetLink(token string) string {
+	if uc.config.PasswordReset.URL == "" {
+		return token
+	}
+	return strings.ReplaceAll(uc.config.PasswordReset.URL, "{token}", token)
+}
+
+// generateOneTimeToken returns 256 random bits, URL-safe encoded (reset and verification links)
+func generateOneTimeToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,70 @@
+package biz
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// heldMailer delivers messages only once the test releases them
+type heldMailer struct {
+	release chan struct{}
+	sent    chan *MailMessage
+}
+
+func (m *heldMailer) Send(ctx context.Context, msg *MailMessage) error {
+	select {
+	case <-m.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	m.sent <- msg
+	return nil
+}
+
+func TestRequestPasswordResetDoesNotWaitForTheMail(t *testing.T) {
+	store := newMemStore()
+	mailer := &heldMailer{release: make(chan struct{}), sent: make(chan *MailMessage, 1)}
+	uc := NewAuthUsecase(newFakeAuthRepos(store), mailer, nil, nil, NewAuthConfigFromConf(nil),
+		jwt.NewHMACKeySet([]byte("test-secret")), log.NewStdLogger(io.Discard))
+	user := store.addUser(&User{Email: "reset@example.com", Username: "reset", Role: RoleUser})
+
+	// A registered account answers as fast as an unknown one, before its mail is out
+	for _, email := range []string{"unknown@example.com", user.Email} {
+		done := make(chan error, 1)
+		go func() { done <- uc.RequestPasswordReset(context.Background(), email, "203.0.113.25") }()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("%s: %v", email, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: request waited for the mail", email)
+		}
+	}
+
+	close(mailer.release)
+	select {
+	case msg := <-mailer.sent:
+		if msg.To != user.Email {
+			t.Fatalf("mail sent to %s, want %s", msg.To, user.Email)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reset mail never sent")
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.resets) != 1 || store.resets[0].UserID != user.ID {
+		t.Fatalf("reset tokens = %+v, want one for the registered account", store.resets)
+	}
+	select {
+	case msg := <-mailer.sent:
+		t.Fatalf("mail sent for an unknown account: %+v", msg)
+	default:
+	}
+}
@@ -0,0 +1,442 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	ErrRoleNotFound       = errors.NotFound("ROLE_NOT_FOUND", "role not found")
+	ErrRoleAlreadyExists  = errors.Conflict("ROLE_ALREADY_EXISTS", "role already exists")
+	ErrRoleInUse          = errors.Conflict("ROLE_IN_USE", "role is a built-in role or the primary role of users")
+	ErrInvalidRoleName    = errors.BadRequest("INVALID_ROLE_NAME", "role name must be 2-50 lowercase letters, digits, '_' or '-'")
+	ErrUnknownPermission  = errors.BadRequest("UNKNOWN_PERMISSION", "unknown permission")
+	ErrPrimaryRoleRemoval = errors.BadRequest("PRIMARY_ROLE", "the primary role of a user cannot be removed, change the user's role first")
+	ErrRoleShared         = errors.Forbidden("ROLE_SHARED", "shared roles are read-only")
+)
+
+// Built-in roles (cannot be deleted)
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user" // Default role of new users
+)
+
+// Permissions checked by the server, named "<resource>:<action>".
+// They are seeded by migrations; roles are managed through the RoleService.
+const (
+	PermissionUserRead          = "user:read"
+	PermissionUserWrite         = "user:write"
+	PermissionUserAdmin         = "user:admin" // Reset passwords, unlock accounts
+	PermissionUserImpersonate   = "user:impersonate"
+	PermissionUserReadSensitive = "user:read_sensitive" // Private profile fields of other users (see (authz.redact))
+	PermissionAuditRead         = "audit:read"          // Audit ids (created_by, updated_by) of records
+	PermissionSessionAdmin      = "session:admin"       // Sessions of other users
+	PermissionRoleAdmin         = "role:admin"          // Roles and role assignments
+	PermissionCountryRead       = "country:read"
+	PermissionCountryWrite      = "country:write"
+	PermissionProvinceRead      = "province:read"
+	PermissionProvinceWrite     = "province:write"
+	PermissionWardRead          = "ward:read"
+	PermissionWardWrite         = "ward:write"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// Permission is a named right to call a group of operations, e.g. "ward:write"
+type Permission struct {
+	BaseEntity
+
+	Name        string `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	Description string `gorm:"type:varchar(255)" json:"description"`
+}
+
+// TenantShared: permissions are defined by the server and shared by every tenant
+func (Permission) TenantShared() bool {
+	return true
+}
+
+// Role is a named set of permissions; a user can hold several roles
+type Role struct {
+	BaseEntity
+
+	Name        string        `gorm:"type:varchar(50);not null" json:"name"` // Unique per tenant and among shared roles
+	Description string        `gorm:"type:varchar(255)" json:"description"`
+	System      bool          `gorm:"not null;default:false" json:"system"` // Built-in, cannot be deleted
+	Permissions []*Permission `gorm:"many2many:role_permissions" json:"permissions,omitempty"`
+}
+
+// TenantShared: built-in roles have no tenant and are shared; roles created by a tenant are its own
+func (Role) TenantShared() bool {
+	return true
+}
+
+// PermissionNames returns the names of the role's permissions
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// RoleCommandRepo for write operations
+type RoleCommandRepo interface {
+	SaveRole(context.Context, *Role) (*Role, error)
+	UpdateRole(context.Context, *Role) (*Role, error)
+	// SetRolePermissions replaces the permissions of a role
+	SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error
+	// DeleteRole removes the role and its assignments
+	DeleteRole(context.Context, uuid.UUID) error
+	// AssignUserRole gives a role to a user; assigning a held role is a no-op
+	AssignUserRole(ctx context.Context, userID, roleID uuid.UUID) error
+	RemoveUserRole(ctx context.Context, userID, roleID uuid.UUID) error
+}
+
+// RoleQueryRepo for read operations. Roles are returned with their permissions.
+type RoleQueryRepo interface {
+	ListPermissions(context.Context) ([]*Permission, error)
+	ListRoles(context.Context) ([]*Role, error)
+	FindRoleByID(context.Context, uuid.UUID) (*Role, error)
+	FindRoleByName(context.Context, string) (*Role, error)
+	// CountUsersWithPrimaryRole counts users whose users.role is the role
+	CountUsersWithPrimaryRole(context.Context, string) (int64, error)
+	ListUserRoles(context.Context, uuid.UUID) ([]*Role, error)
+	// ListUserPermissions returns the distinct permissions of every role of a user
+	ListUserPermissions(context.Context, uuid.UUID) ([]string, error)
+}
+
+// RoleUsecase manages roles, their permissions and role assignments (role:admin)
+type RoleUsecase struct {
+	commandRepo   RoleCommandRepo
+	queryRepo     RoleQueryRepo
+	userQueryRepo UserQueryRepo
+	auditRepo     AuditLogCommandRepo
+	log           *log.Helper
+}
+
+// NewRoleUsecase creates a new RoleUsecase
+func NewRoleUsecase(
+	commandRepo RoleCommandRepo,
+	queryRepo RoleQueryRepo,
+	userQueryRepo UserQueryRepo,
+	auditRepo AuditLogCommandRepo,
+	logger log.Logger,
+) *RoleUsecase {
+	return &RoleUsecase{
+		commandRepo:   commandRepo,
+		queryRepo:     queryRepo,
+		userQueryRepo: userQueryRepo,
+		auditRepo:     auditRepo,
+		log:           log.NewHelper(logger),
+	}
+}
+
+// ListPermissions lists every permission known to the server
+func (uc *RoleUsecase) ListPermissions(ctx context.Context) ([]*Permission, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	return uc.queryRepo.ListPermissions(ctx)
+}
+
+// ListRoles lists every role with its permissions
+func (uc *RoleUsecase) ListRoles(ctx context.Context) ([]*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	return uc.queryRepo.ListRoles(ctx)
+}
+
+// GetRole gets a role with its permissions
+func (uc *RoleUsecase) GetRole(ctx context.Context, id uuid.UUID) (*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	return uc.findRole(ctx, id)
+}
+
+// CreateRole creates a role with the given permissions
+func (uc *RoleUsecase) CreateRole(ctx context.Context, role *Role, permissions []string) (*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+	if !roleNamePattern.MatchString(role.Name) {
+		return nil, ErrInvalidRoleName
+	}
+	existing, err := uc.queryRepo.FindRoleByName(ctx, role.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrRoleAlreadyExists
+	}
+	permissionIDs, err := uc.resolvePermissions(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role.System = false
+	role.SetAuditFields(ctx, true)
+	created, err := uc.commandRepo.SaveRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.commandRepo.SetRolePermissions(ctx, created.ID, permissionIDs); err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, AuditActionRoleCreate, "role", created.ID, map[string]interface{}{"name": created.Name, "permissions": permissions})
+	return uc.queryRepo.FindRoleByID(ctx, created.ID)
+}
+
+// UpdateRole changes the description and replaces the permissions of a role
+func (uc *RoleUsecase) UpdateRole(ctx context.Context, id uuid.UUID, description string, permissions []string) (*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+	role, err := uc.findRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role.IsShared() {
+		return nil, ErrRoleShared
+	}
+	permissionIDs, err := uc.resolvePermissions(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Description = description
+	role.SetAuditFields(ctx, false)
+	if _, err := uc.commandRepo.UpdateRole(ctx, role); err != nil {
+		return nil, err
+	}
+	if err := uc.commandRepo.SetRolePermissions(ctx, role.ID, permissionIDs); err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, AuditActionRoleUpdate, "role", role.ID, map[string]interface{}{"name": role.Name, "permissions": permissions})
+	return uc.queryRepo.FindRoleByID(ctx, role.ID)
+}
+
+// DeleteRole deletes a role that is neither built-in nor the primary role of any user
+func (uc *RoleUsecase) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return err
+	}
+	role, err := uc.findRole(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role.System {
+		return ErrRoleInUse
+	}
+	if role.IsShared() {
+		return ErrRoleShared
+	}
+	count, err := uc.queryRepo.CountUsersWithPrimaryRole(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRoleInUse
+	}
+
+	if err := uc.commandRepo.DeleteRole(ctx, role.ID); err != nil {
+		return err
+	}
+	uc.audit(ctx, AuditActionRoleDelete, "role", role.ID, map[string]interface{}{"name": role.Name})
+	return nil
+}
+
+// ListUserRoles lists the roles of a user
+func (uc *RoleUsecase) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := uc.userQueryRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.queryRepo.ListUserRoles(ctx, userID)
+}
+
+// AssignUserRole gives a role to a user. It takes effect with the user's next access token.
+func (uc *RoleUsecase) AssignUserRole(ctx context.Context, userID uuid.UUID, roleName string) ([]*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uc.userQueryRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	role, err := uc.queryRepo.FindRoleByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := uc.commandRepo.AssignUserRole(ctx, userID, role.ID); err != nil {
+		return nil, err
+	}
+	uc.audit(ctx, AuditActionRoleAssign, "user", userID, map[string]interface{}{"role": role.Name})
+	return uc.queryRepo.ListUserRoles(ctx, userID)
+}
+
+// RemoveUserRole takes a role from a user. The primary role (User.Role) cannot be removed.
+func (uc *RoleUsecase) RemoveUserRole(ctx context.Context, userID uuid.UUID, roleName string) ([]*Role, error) {
+	if err := requirePermission(ctx, PermissionRoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return nil, err
+	}
+	user, err := uc.userQueryRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == roleName {
+		return nil, ErrPrimaryRoleRemoval
+	}
+	role, err := uc.queryRepo.FindRoleByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := uc.commandRepo.RemoveUserRole(ctx, userID, role.ID); err != nil {
+		return nil, err
+	}
+	uc.audit(ctx, AuditActionRoleRemove, "user", userID, map[string]interface{}{"role": role.Name})
+	return uc.queryRepo.ListUserRoles(ctx, userID)
+}
+
+func (uc *RoleUsecase) findRole(ctx context.Context, id uuid.UUID) (*Role, error) {
+	role, err := uc.queryRepo.FindRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// resolvePermissions maps permission names to ids, rejecting unknown names
+func (uc *RoleUsecase) resolvePermissions(ctx context.Context, names []string) ([]uuid.UUID, error) {
+	all, err := uc.queryRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]uuid.UUID, len(all))
+	for _, p := range all {
+		byName[p.Name] = p.ID
+	}
+
+	ids := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, ErrUnknownPermission.WithMetadata(map[string]string{"permission": name})
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// audit records a role change; failures are logged only
+func (uc *RoleUsecase) audit(ctx context.Context, action, targetType string, targetID uuid.UUID, details map[string]interface{}) {
+	data, _ := json.Marshal(details)
+	entry := &AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   &targetID,
+		Details:    string(data),
+	}
+	entry.SetAuditFields(ctx, true)
+	entry.ActorID = entry.CreatedBy
+	if _, err := uc.auditRepo.SaveAuditLog(ctx, entry); err != nil {
+		uc.log.WithContext(ctx).Errorf("Failed to audit %s: %v", action, err)
+	}
+}
+
+// requirePermission checks that the caller's token grants the permission
+func requirePermission(ctx context.Context, permission string) error {
+	if !middleware.HasPermission(ctx, permission) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// tokenGrants returns the roles and permissions put in access tokens. Unverified
+// users get only the restricted role when verification restricts access.
+func (uc *AuthUsecase) tokenGrants(ctx context.Context, user *User) (jwt.Grants, error) {
+	tenantID := tenant.OrDefault(user.TenantID)
+	ctx = tenant.NewContext(ctx, tenantID)
+
+	if uc.isEmailRestricted(user) {
+		role, err := uc.roleQueryRepo.FindRoleByName(ctx, uc.config.EmailVerification.RestrictedRole)
+		if err != nil {
+			return jwt.Grants{}, err
+		}
+		grants := jwt.Grants{TenantID: tenantID, Role: uc.config.EmailVerification.RestrictedRole, Roles: []string{uc.config.EmailVerification.RestrictedRole}}
+		if role != nil {
+			grants.Permissions = role.PermissionNames()
+		}
+		return grants, nil
+	}
+
+	roles, err := uc.roleQueryRepo.ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return jwt.Grants{}, err
+	}
+	permissions, err := uc.roleQueryRepo.ListUserPermissions(ctx, user.ID)
+	if err != nil {
+		return jwt.Grants{}, err
+	}
+	grants := jwt.Grants{TenantID: tenantID, Role: user.Role, Roles: make([]string, 0, len(roles)), Permissions: permissions}
+	for _, role := range roles {
+		grants.Roles = append(grants.Roles, role.Name)
+	}
+	return grants, nil
+}
+
+// hasRole reports whether the user holds the role
+func (uc *AuthUsecase) hasRole(ctx context.Context, user *User, roleName string) (bool, error) {
+	roles, err := uc.roleQueryRepo.ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role.Name == roleName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,103 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrSessionNotFound = errors.NotFound("SESSION_NOT_FOUND", "session not found")
+)
+
+// Session is a login session: every refresh token rotated from one login
+// (a token family). Its ID is the family id, also sent as the "sid" claim.
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time  // Login time
+	LastUsedAt *time.Time // Last login/refresh
+	ExpiresAt  time.Time  // Refresh token expiry
+	Current    bool       // Session of the access token making the request
+}
+
+// ListMySessions lists the active sessions of the current user
+func (uc *AuthUsecase) ListMySessions(ctx context.Context) ([]*Session, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrTokenInvalid
+	}
+	return uc.listSessions(ctx, userID)
+}
+
+// RevokeSession revokes one session of the current user
+func (uc *AuthUsecase) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return ErrTokenInvalid
+	}
+	return uc.revokeSession(ctx, userID, sessionID)
+}
+
+// ListUserSessions lists the active sessions of any user (session:admin)
+func (uc *AuthUsecase) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error) {
+	if err := requirePermission(ctx, PermissionSessionAdmin); err != nil {
+		return nil, err
+	}
+	return uc.listSessions(ctx, userID)
+}
+
+// RevokeUserSession revokes one session of any user (session:admin)
+func (uc *AuthUsecase) RevokeUserSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := requirePermission(ctx, PermissionSessionAdmin); err != nil {
+		return err
+	}
+	return uc.revokeSession(ctx, userID, sessionID)
+}
+
+func (uc *AuthUsecase) listSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error) {
+	sessions, err := uc.authQueryRepo.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current, ok := middleware.GetSessionIDFromContext(ctx); ok {
+		for _, session := range sessions {
+			session.Current = session.ID == current
+		}
+	}
+	return sessions, nil
+}
+
+func (uc *AuthUsecase) revokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	sessions, err := uc.authQueryRepo.ListUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Only sessions of this user can be revoked
+	found := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrSessionNotFound
+	}
+
+	if err := uc.authCommandRepo.RevokeTokenFamily(ctx, sessionID); err != nil {
+		return err
+	}
+	uc.markSessionRevoked(ctx, sessionID)
+
+	uc.log.WithContext(ctx).Infof("Session %s of user %s revoked", sessionID.String(), userID.String())
+	return nil
+}
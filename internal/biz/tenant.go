@@ -0,0 +1,42 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Tenant is an organisation hosted on the deployment. Every BaseEntity row belongs
+// to one through TenantID, repositories are scoped to the tenant of the request context.
+// Tenants are provisioned by the operator (see migrations/019_add_multi_tenancy.sql).
+type Tenant struct {
+	ID        uuid.UUID `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	Name      string    `gorm:"type:varchar(200);not null" json:"name"`
+	Status    string    `gorm:"type:varchar(20);default:'active'" json:"status"`
+}
+
+// TenantQueryRepo for read operations
+type TenantQueryRepo interface {
+	// FindTenantByID returns nil, nil when the tenant does not exist
+	FindTenantByID(context.Context, uuid.UUID) (*Tenant, error)
+}
+
+// TenantExists reports whether requests may name the tenant (X-Tenant-ID header),
+// implements middleware.TenantChecker
+func (uc *AuthUsecase) TenantExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	t, err := uc.tenantQueryRepo.FindTenantByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return t != nil && t.Status == "active", nil
+}
+
+// inRecordTenant scopes ctx to the tenant of a record that was found by a globally
+// unique secret (refresh token, API key, one-time token) under tenant.WithAllTenants
+func inRecordTenant(ctx context.Context, record *BaseEntity) context.Context {
+	return tenant.NewContext(ctx, tenant.OrDefault(record.TenantID))
+}
@@ -0,0 +1,127 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// TokenCleanupConfig configures the periodic purge of old auth tokens
+type TokenCleanupConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	Retention time.Duration // Kept this long after refresh expiry or revocation, at least the refresh token expiry
+	BatchSize int
+	Archive   bool // Move rows to auth_tokens_archive instead of deleting them
+}
+
+// newTokenCleanupConfigFromConf raises the retention to refreshExpiry: a rotated refresh
+// token must outlive its expiry, otherwise replaying it is no longer recognised as reuse
+// and its session family is not revoked.
+func newTokenCleanupConfigFromConf(c *conf.Auth_TokenCleanup, refreshExpiry time.Duration) TokenCleanupConfig {
+	cfg := TokenCleanupConfig{
+		Interval:  time.Hour,
+		Retention: 30 * 24 * time.Hour,
+		BatchSize: 1000,
+	}
+	if c != nil {
+		cfg.Enabled = c.Enabled
+		cfg.Archive = c.Archive
+		if c.Interval != nil && c.Interval.AsDuration() > 0 {
+			cfg.Interval = c.Interval.AsDuration()
+		}
+		if c.Retention != nil && c.Retention.AsDuration() > 0 {
+			cfg.Retention = c.Retention.AsDuration()
+		}
+		if c.BatchSize > 0 {
+			cfg.BatchSize = int(c.BatchSize)
+		}
+	}
+	if cfg.Retention < refreshExpiry {
+		cfg.Retention = refreshExpiry
+	}
+	return cfg
+}
+
+// TokenCleanupRepo removes old auth tokens
+type TokenCleanupRepo interface {
+	// RunExclusive runs fn while holding the cluster-wide cleanup lock. It returns
+	// false without running fn if another instance holds the lock.
+	RunExclusive(context.Context, func(context.Context) error) (bool, error)
+	// PurgeTokens deletes (or archives) up to limit tokens whose refresh token
+	// expired, or which were revoked, before the cutoff. Returns the number of rows removed.
+	PurgeTokens(ctx context.Context, cutoff time.Time, limit int, archive bool) (int64, error)
+}
+
+// TokenCleanupUsecase purges expired and revoked tokens so auth_tokens does not grow forever
+type TokenCleanupUsecase struct {
+	repo   TokenCleanupRepo
+	config TokenCleanupConfig
+	log    *log.Helper
+}
+
+func NewTokenCleanupUsecase(repo TokenCleanupRepo, c *conf.Auth, logger log.Logger) *TokenCleanupUsecase {
+	var tc *conf.Auth_TokenCleanup
+	refreshExpiry := time.Duration(NewAuthConfigFromConf(nil).RefreshExpiry) * time.Second
+	if c != nil {
+		tc = c.TokenCleanup
+		refreshExpiry = time.Duration(c.RefreshTokenExpiry) * time.Second
+	}
+	uc := &TokenCleanupUsecase{
+		repo:   repo,
+		config: newTokenCleanupConfigFromConf(tc, refreshExpiry),
+		log:    log.NewHelper(logger),
+	}
+	if tc.GetRetention().AsDuration() > 0 && tc.GetRetention().AsDuration() < uc.config.Retention {
+		uc.log.Warnf("auth.token_cleanup.retention %s is shorter than the refresh token expiry, using %s",
+			tc.GetRetention().AsDuration(), uc.config.Retention)
+	}
+	return uc
+}
+
+// Config returns the cleanup settings
+func (uc *TokenCleanupUsecase) Config() TokenCleanupConfig {
+	return uc.config
+}
+
+// PurgeTokens removes tokens past the retention period in batches until none are
+// left. Only one instance purges at a time; the others skip the run and report
+// leader=false. Removed is the number of rows deleted (or archived).
+func (uc *TokenCleanupUsecase) PurgeTokens(ctx context.Context) (removed int64, leader bool, err error) {
+	cutoff := time.Now().Add(-uc.config.Retention)
+
+	leader, err = uc.repo.RunExclusive(ctx, func(ctx context.Context) error {
+		for {
+			n, err := uc.repo.PurgeTokens(ctx, cutoff, uc.config.BatchSize, uc.config.Archive)
+			removed += n
+			if err != nil {
+				return err
+			}
+			// A short batch means nothing is left
+			if n < int64(uc.config.BatchSize) {
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Token cleanup stopped after removing %d tokens: %v", removed, err)
+		return removed, leader, err
+	}
+	if !leader {
+		uc.log.WithContext(ctx).Debug("Token cleanup skipped, another instance holds the lock")
+		return 0, false, nil
+	}
+
+	action := "deleted"
+	if uc.config.Archive {
+		action = "archived"
+	}
+	uc.log.WithContext(ctx).Infof("Token cleanup %s %d tokens expired or revoked before %s", action, removed, cutoff.UTC().Format(time.RFC3339))
+	return removed, true, nil
+}
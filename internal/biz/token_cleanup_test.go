@@ -0,0 +1,37 @@
+package biz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestTokenCleanupRetentionCoversRefreshExpiry(t *testing.T) {
+	const week = 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      time.Duration
+	}{
+		{"default", 0, 30 * 24 * time.Hour},
+		{"longer than the refresh expiry", 2 * week, 2 * week},
+		{"equal to the refresh expiry", week, week},
+		// Rotated tokens would be purged while a replay must still revoke their session
+		{"shorter than the refresh expiry", 24 * time.Hour, week},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &conf.Auth_TokenCleanup{}
+			if tt.retention > 0 {
+				c.Retention = durationpb.New(tt.retention)
+			}
+			if got := newTokenCleanupConfigFromConf(c, week).Retention; got != tt.want {
+				t.Fatalf("retention = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
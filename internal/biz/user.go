@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/password"
 	"github.com/gofrs/uuid/v5"
 
 	"github.com/go-kratos/kratos/v2/errors"
@@ -13,6 +15,16 @@ import (
 var (
 	ErrUserAlreadyExists = errors.Conflict("USER_ALREADY_EXISTS", "user already exists")
 	ErrInvalidPassword   = errors.Unauthorized("INVALID_PASSWORD", "invalid password")
+	ErrForbidden         = errors.Forbidden("FORBIDDEN", "insufficient permissions")
+	ErrNotOwner          = errors.Forbidden("FORBIDDEN", "users can only access their own record")
+	ErrFieldForbidden    = errors.Forbidden("FORBIDDEN", "only admins may change this field")
+	ErrInvalidStatus     = errors.BadRequest("INVALID_STATUS", "status must be active or inactive")
+)
+
+// User statuses that can be set through UpdateUser
+const (
+	UserStatusActive   = "active"
+	UserStatusInactive = "inactive"
 )
 
 // User là domain model cho User
@@ -20,9 +32,9 @@ type User struct {
 	BaseEntity
 
 	// Thông tin cơ bản
-	Email        string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
-	Username     string `gorm:"type:varchar(100);uniqueIndex;not null" json:"username"`
-	PasswordHash string `gorm:"type:varchar(255);not null" json:"-"` // Ẩn trong JSON
+	Email        string `gorm:"type:varchar(255);not null" json:"email"`    // Unique per tenant
+	Username     string `gorm:"type:varchar(100);not null" json:"username"` // Unique per tenant
+	PasswordHash string `gorm:"type:varchar(255);not null" json:"-"`        // Ẩn trong JSON
 
 	// Thông tin cá nhân
 	FullName string `gorm:"type:varchar(200);index" json:"full_name,omitempty"`
@@ -31,11 +43,21 @@ type User struct {
 	DateOfBirth *time.Time `gorm:"type:date" json:"date_of_birth,omitempty"`
 	Gender      string     `gorm:"type:varchar(20)" json:"gender,omitempty"` // male, female, other
 
+	// Xác thực email (nil = chưa xác thực)
+	EmailVerifiedAt *time.Time `gorm:"type:timestamp" json:"email_verified_at,omitempty"`
+
+	// Khoá tài khoản khi nhập sai mật khẩu nhiều lần
+	FailedLoginAttempts int        `gorm:"not null;default:0" json:"-"`
+	LockedUntil         *time.Time `gorm:"type:timestamp" json:"locked_until,omitempty"`
+
+	// Thời điểm đổi mật khẩu gần nhất (nil = dùng CreatedAt), cho password expiry
+	PasswordChangedAt *time.Time `gorm:"type:timestamp" json:"password_changed_at,omitempty"`
+
 	// Last login tracking
 	LastLoginAt *time.Time `gorm:"type:timestamp;index" json:"last_login_at,omitempty"`
 	LastLoginIP string     `gorm:"type:varchar(45)" json:"last_login_ip,omitempty"`
 
-	// Role và permissions
+	// Primary role, also held in user_roles; permissions come from every role of the user (see Role)
 	Role string `gorm:"type:varchar(50);default:'user';index" json:"role"` // user, admin, moderator
 }
 
@@ -47,9 +69,14 @@ func (u *User) GetDisplayName() string {
 	return u.Username
 }
 
-// IsAdmin checks if user is admin
-func (u *User) IsAdmin() bool {
-	return u.Role == "admin"
+// IsEmailVerified checks if the email address has been verified
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsLocked checks if the account is temporarily locked
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && time.Now().Before(*u.LockedUntil)
 }
 
 // UserCommandRepo là repository interface cho write operations
@@ -57,8 +84,18 @@ type UserCommandRepo interface {
 	Save(context.Context, *User) (*User, error)
 	Update(context.Context, *User) (*User, error)
 	Delete(context.Context, uuid.UUID) error
+	// UpdatePassword moves the current hash to password_history and sets the new one
 	UpdatePassword(context.Context, uuid.UUID, string) error
+	// RehashPassword replaces the hash of the same password (algorithm upgrade),
+	// without touching the history or password_changed_at
+	RehashPassword(context.Context, uuid.UUID, string) error
 	UpdateLastLogin(context.Context, uuid.UUID, string) error
+	MarkEmailVerified(context.Context, uuid.UUID) error
+	// IncrementFailedLogins returns the new number of consecutive failed logins
+	IncrementFailedLogins(context.Context, uuid.UUID) (int, error)
+	LockUntil(context.Context, uuid.UUID, time.Time) error
+	// ResetFailedLogins clears the counter and any lock
+	ResetFailedLogins(context.Context, uuid.UUID) error
 }
 
 // UserQueryRepo là repository interface cho read operations
@@ -68,6 +105,8 @@ type UserQueryRepo interface {
 	FindByUsername(context.Context, string) (*User, error)
 	List(context.Context, *UserListFilter) ([]*User, int64, error)
 	Count(context.Context, *UserListFilter) (int64, error)
+	// ListPasswordHistory returns the most recent previous password hashes, newest first
+	ListPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
 }
 
 // UserListFilter cho pagination và filtering
@@ -81,28 +120,51 @@ type UserListFilter struct {
 
 // UserUsecase là usecase cho User với CQRS pattern
 type UserUsecase struct {
-	commandRepo UserCommandRepo
-	queryRepo   UserQueryRepo
-	log         *log.Helper
+	commandRepo    UserCommandRepo
+	queryRepo      UserQueryRepo
+	roleQueryRepo  RoleQueryRepo
+	auditRepo      AuditLogCommandRepo
+	passwordPolicy PasswordPolicyConfig
+	breached       BreachedPasswordChecker
+	log            *log.Helper
 }
 
 // NewUserUsecase tạo UserUsecase mới
 func NewUserUsecase(
 	commandRepo UserCommandRepo,
 	queryRepo UserQueryRepo,
+	roleQueryRepo RoleQueryRepo,
+	auditRepo AuditLogCommandRepo,
+	breached BreachedPasswordChecker,
+	authConfig *AuthConfig,
 	logger log.Logger,
 ) *UserUsecase {
 	return &UserUsecase{
-		commandRepo: commandRepo,
-		queryRepo:   queryRepo,
-		log:         log.NewHelper(logger),
+		commandRepo:    commandRepo,
+		queryRepo:      queryRepo,
+		roleQueryRepo:  roleQueryRepo,
+		auditRepo:      auditRepo,
+		passwordPolicy: authConfig.PasswordPolicy,
+		breached:       breached,
+		log:            log.NewHelper(logger),
 	}
 }
 
-// CreateUser creates a new user (Command)
-func (uc *UserUsecase) CreateUser(ctx context.Context, user *User) (*User, error) {
+// CreateUser creates a new user with a password (Command).
+// The password must already be validated; it is hashed here.
+func (uc *UserUsecase) CreateUser(ctx context.Context, user *User, plainPassword string) (*User, error) {
 	uc.log.WithContext(ctx).Infof("CreateUser: %s", user.Email)
 
+	if err := checkPasswordBreached(uc.breached, plainPassword); err != nil {
+		return nil, err
+	}
+	if user.Role != RoleUser && !middleware.HasPermission(ctx, PermissionRoleAdmin) {
+		return nil, ErrFieldForbidden.WithMetadata(map[string]string{"field": "role"})
+	}
+	if err := uc.checkRole(ctx, user.Role); err != nil {
+		return nil, err
+	}
+
 	// Set audit fields from context
 	user.SetAuditFields(ctx, true)
 
@@ -117,12 +179,50 @@ func (uc *UserUsecase) CreateUser(ctx context.Context, user *User) (*User, error
 		return nil, ErrUserAlreadyExists
 	}
 
+	passwordHash, err := password.Hash(plainPassword)
+	if err != nil {
+		return nil, errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+	}
+	user.PasswordHash = passwordHash
+
+	// Password age starts now
+	if user.PasswordChangedAt == nil {
+		now := time.Now()
+		user.PasswordChangedAt = &now
+	}
+
 	return uc.commandRepo.Save(ctx, user)
 }
 
-// UpdateUser updates a user (Command)
+// UpdateUser updates a user (Command).
+// Users may update their own record, user:write is needed for others.
+// Changing Role needs role:admin and changing Status needs user:admin.
 func (uc *UserUsecase) UpdateUser(ctx context.Context, user *User) (*User, error) {
 	uc.log.WithContext(ctx).Infof("UpdateUser: %s", user.ID.String())
+
+	if err := authorizeUser(ctx, user.ID, PermissionUserWrite); err != nil {
+		return nil, err
+	}
+	current, err := uc.queryRepo.FindByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != current.Role {
+		if !middleware.HasPermission(ctx, PermissionRoleAdmin) {
+			return nil, ErrFieldForbidden.WithMetadata(map[string]string{"field": "role"})
+		}
+		if err := uc.checkRole(ctx, user.Role); err != nil {
+			return nil, err
+		}
+	}
+	if user.Status != current.Status {
+		if !middleware.HasPermission(ctx, PermissionUserAdmin) {
+			return nil, ErrFieldForbidden.WithMetadata(map[string]string{"field": "status"})
+		}
+		if user.Status != UserStatusActive && user.Status != UserStatusInactive {
+			return nil, ErrInvalidStatus
+		}
+	}
 	
 	// Set audit fields from context
 	user.SetAuditFields(ctx, false)
@@ -136,10 +236,69 @@ func (uc *UserUsecase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return uc.commandRepo.Delete(ctx, id)
 }
 
-// ChangePassword changes user password (Command)
-func (uc *UserUsecase) ChangePassword(ctx context.Context, id uuid.UUID, newPasswordHash string) error {
+// ChangePassword resets the password of any user (Command, user:admin).
+// The new password must already be validated; it is checked against the password history.
+// Users change their own password with AuthUsecase.ChangeMyPassword.
+func (uc *UserUsecase) ChangePassword(ctx context.Context, id uuid.UUID, newPassword, ip string) error {
+	if err := requirePermission(ctx, PermissionUserAdmin); err != nil {
+		return err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return err
+	}
+
+	user, err := uc.queryRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPasswordBreached(uc.breached, newPassword); err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(ctx, uc.queryRepo, uc.passwordPolicy, user, newPassword); err != nil {
+		return err
+	}
+
+	newPasswordHash, err := password.Hash(newPassword)
+	if err != nil {
+		return errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+	}
+
 	uc.log.WithContext(ctx).Infof("ChangePassword: %s", id.String())
-	return uc.commandRepo.UpdatePassword(ctx, id, newPasswordHash)
+	if err := uc.commandRepo.UpdatePassword(ctx, id, newPasswordHash); err != nil {
+		return err
+	}
+
+	// Record who reset the password
+	entry := &AuditLog{
+		Action:     AuditActionAdminPasswordReset,
+		TargetType: "user",
+		TargetID:   &id,
+		IPAddress:  ip,
+	}
+	entry.SetAuditFields(ctx, true)
+	entry.ActorID = entry.CreatedBy
+	if _, err := uc.auditRepo.SaveAuditLog(ctx, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnlockUser clears the failed-login counter and lock of an account (Command, user:admin)
+func (uc *UserUsecase) UnlockUser(ctx context.Context, id uuid.UUID) error {
+	if err := requirePermission(ctx, PermissionUserAdmin); err != nil {
+		return err
+	}
+	if err := forbidImpersonation(ctx); err != nil {
+		return err
+	}
+
+	if _, err := uc.queryRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	uc.log.WithContext(ctx).Infof("UnlockUser: %s", id.String())
+	return uc.commandRepo.ResetFailedLogins(ctx, id)
 }
 
 // UpdateLastLogin updates last login info (Command)
@@ -147,8 +306,11 @@ func (uc *UserUsecase) UpdateLastLogin(ctx context.Context, id uuid.UUID, ip str
 	return uc.commandRepo.UpdateLastLogin(ctx, id, ip)
 }
 
-// GetUser gets a user by ID (Query)
+// GetUser gets a user by ID (Query). Users may read their own record, user:read is needed for others.
 func (uc *UserUsecase) GetUser(ctx context.Context, id uuid.UUID) (*User, error) {
+	if err := authorizeUser(ctx, id, PermissionUserRead); err != nil {
+		return nil, err
+	}
 	return uc.queryRepo.FindByID(ctx, id)
 }
 
@@ -167,3 +329,29 @@ func (uc *UserUsecase) ListUsers(ctx context.Context, filter *UserListFilter) ([
 	return uc.queryRepo.List(ctx, filter)
 }
 
+// checkRole checks that the primary role of a user exists (empty keeps the current role)
+func (uc *UserUsecase) checkRole(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+	role, err := uc.roleQueryRepo.FindRoleByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return ErrRoleNotFound
+	}
+	return nil
+}
+
+// authorizeUser lets the caller act on their own record, or on any record with the permission.
+// During impersonation the caller is the impersonated user.
+func authorizeUser(ctx context.Context, id uuid.UUID, permission string) error {
+	if callerID, ok := middleware.GetUserIDFromContext(ctx); ok && callerID == id {
+		return nil
+	}
+	if middleware.HasPermission(ctx, permission) {
+		return nil
+	}
+	return ErrNotOwner
+}
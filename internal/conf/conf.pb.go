@@ -27,6 +27,7 @@ type Bootstrap struct {
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	Auth          *Auth                  `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth,omitempty"`
+	Mail          *Mail                  `protobuf:"bytes,4,opt,name=mail,proto3" json:"mail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +83,13 @@ func (x *Bootstrap) GetAuth() *Auth {
 	return nil
 }
 
+func (x *Bootstrap) GetMail() *Mail {
+	if x != nil {
+		return x.Mail
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
@@ -204,11 +212,30 @@ func (x *Data) GetRedis() *Data_Redis {
 
 type Auth struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
-	JwtSecret          string                 `protobuf:"bytes,1,opt,name=jwt_secret,json=jwtSecret,proto3" json:"jwt_secret,omitempty"`
+	JwtSecret          string                 `protobuf:"bytes,1,opt,name=jwt_secret,json=jwtSecret,proto3" json:"jwt_secret,omitempty"`                               // HS256 secret, used only when signing_keys is empty
 	AccessTokenExpiry  int64                  `protobuf:"varint,2,opt,name=access_token_expiry,json=accessTokenExpiry,proto3" json:"access_token_expiry,omitempty"`    // seconds, default 3600 (1 hour)
 	RefreshTokenExpiry int64                  `protobuf:"varint,3,opt,name=refresh_token_expiry,json=refreshTokenExpiry,proto3" json:"refresh_token_expiry,omitempty"` // seconds, default 604800 (7 days)
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	TokenPepper        string                 `protobuf:"bytes,4,opt,name=token_pepper,json=tokenPepper,proto3" json:"token_pepper,omitempty"`                         // HMAC key for hashing stored tokens, defaults to jwt_secret
+	ActiveKeyId        string                 `protobuf:"bytes,5,opt,name=active_key_id,json=activeKeyId,proto3" json:"active_key_id,omitempty"`                       // kid of the signing key used for new tokens
+	SigningKeys        []*Auth_SigningKey     `protobuf:"bytes,6,rep,name=signing_keys,json=signingKeys,proto3" json:"signing_keys,omitempty"`                         // All keys accepted for verification (published in JWKS)
+	// Access-token revocation cache (used when redis is not configured)
+	RevocationCacheTtl  *durationpb.Duration    `protobuf:"bytes,7,opt,name=revocation_cache_ttl,json=revocationCacheTtl,proto3" json:"revocation_cache_ttl,omitempty"`     // How long an "active" session is cached, default 30s
+	RevocationCacheSize int32                   `protobuf:"varint,8,opt,name=revocation_cache_size,json=revocationCacheSize,proto3" json:"revocation_cache_size,omitempty"` // Max cached sessions, default 10000
+	Mfa                 *Auth_MFA               `protobuf:"bytes,9,opt,name=mfa,proto3" json:"mfa,omitempty"`
+	PasswordReset       *Auth_PasswordReset     `protobuf:"bytes,10,opt,name=password_reset,json=passwordReset,proto3" json:"password_reset,omitempty"`
+	EmailVerification   *Auth_EmailVerification `protobuf:"bytes,11,opt,name=email_verification,json=emailVerification,proto3" json:"email_verification,omitempty"`
+	Lockout             *Auth_Lockout           `protobuf:"bytes,12,opt,name=lockout,proto3" json:"lockout,omitempty"`
+	ApiKeys             *Auth_APIKeys           `protobuf:"bytes,13,opt,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	Oidc                *Auth_OIDC              `protobuf:"bytes,14,opt,name=oidc,proto3" json:"oidc,omitempty"`
+	PasswordHash        *Auth_PasswordHash      `protobuf:"bytes,15,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	PasswordPolicy      *Auth_PasswordPolicy    `protobuf:"bytes,16,opt,name=password_policy,json=passwordPolicy,proto3" json:"password_policy,omitempty"`
+	BreachedPasswords   *Auth_BreachedPasswords `protobuf:"bytes,17,opt,name=breached_passwords,json=breachedPasswords,proto3" json:"breached_passwords,omitempty"`
+	Impersonation       *Auth_Impersonation     `protobuf:"bytes,18,opt,name=impersonation,proto3" json:"impersonation,omitempty"`
+	Introspection       *Auth_Introspection     `protobuf:"bytes,19,opt,name=introspection,proto3" json:"introspection,omitempty"`
+	TokenCleanup        *Auth_TokenCleanup      `protobuf:"bytes,20,opt,name=token_cleanup,json=tokenCleanup,proto3" json:"token_cleanup,omitempty"`
+	LoginNotification   *Auth_LoginNotification `protobuf:"bytes,21,opt,name=login_notification,json=loginNotification,proto3" json:"login_notification,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Auth) Reset() {
@@ -262,6 +289,200 @@ func (x *Auth) GetRefreshTokenExpiry() int64 {
 	return 0
 }
 
+func (x *Auth) GetTokenPepper() string {
+	if x != nil {
+		return x.TokenPepper
+	}
+	return ""
+}
+
+func (x *Auth) GetActiveKeyId() string {
+	if x != nil {
+		return x.ActiveKeyId
+	}
+	return ""
+}
+
+func (x *Auth) GetSigningKeys() []*Auth_SigningKey {
+	if x != nil {
+		return x.SigningKeys
+	}
+	return nil
+}
+
+func (x *Auth) GetRevocationCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.RevocationCacheTtl
+	}
+	return nil
+}
+
+func (x *Auth) GetRevocationCacheSize() int32 {
+	if x != nil {
+		return x.RevocationCacheSize
+	}
+	return 0
+}
+
+func (x *Auth) GetMfa() *Auth_MFA {
+	if x != nil {
+		return x.Mfa
+	}
+	return nil
+}
+
+func (x *Auth) GetPasswordReset() *Auth_PasswordReset {
+	if x != nil {
+		return x.PasswordReset
+	}
+	return nil
+}
+
+func (x *Auth) GetEmailVerification() *Auth_EmailVerification {
+	if x != nil {
+		return x.EmailVerification
+	}
+	return nil
+}
+
+func (x *Auth) GetLockout() *Auth_Lockout {
+	if x != nil {
+		return x.Lockout
+	}
+	return nil
+}
+
+func (x *Auth) GetApiKeys() *Auth_APIKeys {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+func (x *Auth) GetOidc() *Auth_OIDC {
+	if x != nil {
+		return x.Oidc
+	}
+	return nil
+}
+
+func (x *Auth) GetPasswordHash() *Auth_PasswordHash {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return nil
+}
+
+func (x *Auth) GetPasswordPolicy() *Auth_PasswordPolicy {
+	if x != nil {
+		return x.PasswordPolicy
+	}
+	return nil
+}
+
+func (x *Auth) GetBreachedPasswords() *Auth_BreachedPasswords {
+	if x != nil {
+		return x.BreachedPasswords
+	}
+	return nil
+}
+
+func (x *Auth) GetImpersonation() *Auth_Impersonation {
+	if x != nil {
+		return x.Impersonation
+	}
+	return nil
+}
+
+func (x *Auth) GetIntrospection() *Auth_Introspection {
+	if x != nil {
+		return x.Introspection
+	}
+	return nil
+}
+
+func (x *Auth) GetTokenCleanup() *Auth_TokenCleanup {
+	if x != nil {
+		return x.TokenCleanup
+	}
+	return nil
+}
+
+func (x *Auth) GetLoginNotification() *Auth_LoginNotification {
+	if x != nil {
+		return x.LoginNotification
+	}
+	return nil
+}
+
+type Mail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"` // smtp or outbox (default)
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`     // Sender address
+	Smtp          *Mail_SMTP             `protobuf:"bytes,3,opt,name=smtp,proto3" json:"smtp,omitempty"`
+	OutboxDir     string                 `protobuf:"bytes,4,opt,name=outbox_dir,json=outboxDir,proto3" json:"outbox_dir,omitempty"` // outbox driver writes every mail as an .eml file here (local development, tests)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mail) Reset() {
+	*x = Mail{}
+	mi := &file_conf_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mail) ProtoMessage() {}
+
+func (x *Mail) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mail.ProtoReflect.Descriptor instead.
+func (*Mail) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Mail) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Mail) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Mail) GetSmtp() *Mail_SMTP {
+	if x != nil {
+		return x.Smtp
+	}
+	return nil
+}
+
+func (x *Mail) GetOutboxDir() string {
+	if x != nil {
+		return x.OutboxDir
+	}
+	return ""
+}
+
 type Server_HTTP struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
@@ -273,7 +494,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -285,7 +506,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -333,7 +554,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -345,7 +566,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,7 +613,7 @@ type Data_Database struct {
 
 func (x *Data_Database) Reset() {
 	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+	mi := &file_conf_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -404,7 +625,7 @@ func (x *Data_Database) String() string {
 func (*Data_Database) ProtoMessage() {}
 
 func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+	mi := &file_conf_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +665,7 @@ type Data_ReadDatabase struct {
 
 func (x *Data_ReadDatabase) Reset() {
 	*x = Data_ReadDatabase{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -456,7 +677,7 @@ func (x *Data_ReadDatabase) String() string {
 func (*Data_ReadDatabase) ProtoMessage() {}
 
 func (x *Data_ReadDatabase) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +717,7 @@ type Data_WriteDatabase struct {
 
 func (x *Data_WriteDatabase) Reset() {
 	*x = Data_WriteDatabase{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -508,7 +729,7 @@ func (x *Data_WriteDatabase) String() string {
 func (*Data_WriteDatabase) ProtoMessage() {}
 
 func (x *Data_WriteDatabase) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -544,13 +765,15 @@ type Data_Redis struct {
 	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
 	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
 	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	Db            int32                  `protobuf:"varint,6,opt,name=db,proto3" json:"db,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -562,7 +785,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -606,51 +829,1344 @@ func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
 	return nil
 }
 
-var File_conf_conf_proto protoreflect.FileDescriptor
+func (x *Data_Redis) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
 
-const file_conf_conf_proto_rawDesc = "" +
-	"\n" +
-	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\x83\x01\n" +
-	"\tBootstrap\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
-	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12$\n" +
-	"\x04auth\x18\x03 \x01(\v2\x10.kratos.api.AuthR\x04auth\"\xb8\x02\n" +
-	"\x06Server\x12+\n" +
-	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
-	"\x04HTTP\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1ai\n" +
-	"\x04GRPC\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xe9\x04\n" +
-	"\x04Data\x125\n" +
-	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12B\n" +
-	"\rread_database\x18\x02 \x01(\v2\x1d.kratos.api.Data.ReadDatabaseR\freadDatabase\x12E\n" +
-	"\x0ewrite_database\x18\x03 \x01(\v2\x1e.kratos.api.Data.WriteDatabaseR\rwriteDatabase\x12,\n" +
-	"\x05redis\x18\x04 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
-	"\bDatabase\x12\x16\n" +
-	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
-	"\x06source\x18\x02 \x01(\tR\x06source\x1a>\n" +
-	"\fReadDatabase\x12\x16\n" +
-	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
-	"\x06source\x18\x02 \x01(\tR\x06source\x1a?\n" +
-	"\rWriteDatabase\x12\x16\n" +
-	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
-	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xb3\x01\n" +
-	"\x05Redis\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
-	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\"\x87\x01\n" +
-	"\x04Auth\x12\x1d\n" +
-	"\n" +
-	"jwt_secret\x18\x01 \x01(\tR\tjwtSecret\x12.\n" +
-	"\x13access_token_expiry\x18\x02 \x01(\x03R\x11accessTokenExpiry\x120\n" +
-	"\x14refresh_token_expiry\x18\x03 \x01(\x03R\x12refreshTokenExpiryB7Z5github.com/go-kratos/kratos-layout/internal/conf;confb\x06proto3"
+func (x *Data_Redis) GetDb() int32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+// SigningKey is an asymmetric JWT key loaded from PEM files
+type Auth_SigningKey struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Kid            string                 `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`                                               // Key id, sent in the JWT "kid" header
+	Algorithm      string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                                   // RS256 or EdDSA
+	PrivateKeyFile string                 `protobuf:"bytes,3,opt,name=private_key_file,json=privateKeyFile,proto3" json:"private_key_file,omitempty"` // PEM private key, required for the active key
+	PublicKeyFile  string                 `protobuf:"bytes,4,opt,name=public_key_file,json=publicKeyFile,proto3" json:"public_key_file,omitempty"`    // PEM public key, enough for retired (verify-only) keys
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Auth_SigningKey) Reset() {
+	*x = Auth_SigningKey{}
+	mi := &file_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_SigningKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_SigningKey) ProtoMessage() {}
+
+func (x *Auth_SigningKey) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_SigningKey.ProtoReflect.Descriptor instead.
+func (*Auth_SigningKey) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Auth_SigningKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *Auth_SigningKey) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Auth_SigningKey) GetPrivateKeyFile() string {
+	if x != nil {
+		return x.PrivateKeyFile
+	}
+	return ""
+}
+
+func (x *Auth_SigningKey) GetPublicKeyFile() string {
+	if x != nil {
+		return x.PublicKeyFile
+	}
+	return ""
+}
+
+// TOTP two-factor authentication
+type Auth_MFA struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Issuer           string                 `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                // Shown in authenticator apps, default "Backend Service"
+	RequireForAdmins bool                   `protobuf:"varint,2,opt,name=require_for_admins,json=requireForAdmins,proto3" json:"require_for_admins,omitempty"` // Admins must enroll before they can log in
+	ChallengeTtl     *durationpb.Duration   `protobuf:"bytes,3,opt,name=challenge_ttl,json=challengeTtl,proto3" json:"challenge_ttl,omitempty"`                // Lifetime of the MFA challenge token, default 5m
+	EncryptionKey    string                 `protobuf:"bytes,4,opt,name=encryption_key,json=encryptionKey,proto3" json:"encryption_key,omitempty"`             // Key for encrypting stored TOTP secrets, defaults to token_pepper
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Auth_MFA) Reset() {
+	*x = Auth_MFA{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_MFA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_MFA) ProtoMessage() {}
+
+func (x *Auth_MFA) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_MFA.ProtoReflect.Descriptor instead.
+func (*Auth_MFA) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Auth_MFA) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Auth_MFA) GetRequireForAdmins() bool {
+	if x != nil {
+		return x.RequireForAdmins
+	}
+	return false
+}
+
+func (x *Auth_MFA) GetChallengeTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ChallengeTtl
+	}
+	return nil
+}
+
+func (x *Auth_MFA) GetEncryptionKey() string {
+	if x != nil {
+		return x.EncryptionKey
+	}
+	return ""
+}
+
+// Self-service password reset
+type Auth_PasswordReset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenTtl      *durationpb.Duration   `protobuf:"bytes,1,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"` // Lifetime of a reset token, default 30m
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                           // Link sent by email, "{token}" is replaced by the token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_PasswordReset) Reset() {
+	*x = Auth_PasswordReset{}
+	mi := &file_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_PasswordReset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_PasswordReset) ProtoMessage() {}
+
+func (x *Auth_PasswordReset) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_PasswordReset.ProtoReflect.Descriptor instead.
+func (*Auth_PasswordReset) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Auth_PasswordReset) GetTokenTtl() *durationpb.Duration {
+	if x != nil {
+		return x.TokenTtl
+	}
+	return nil
+}
+
+func (x *Auth_PasswordReset) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Email verification of new accounts
+type Auth_EmailVerification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Mode           string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`                                           // none (default): not enforced, block: unverified users cannot log in, restrict: unverified users get restricted_role
+	RestrictedRole string                 `protobuf:"bytes,2,opt,name=restricted_role,json=restrictedRole,proto3" json:"restricted_role,omitempty"` // Role in tokens of unverified users in restrict mode, default "unverified"
+	TokenTtl       *durationpb.Duration   `protobuf:"bytes,3,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"`                   // Lifetime of a verification token, default 48h
+	Url            string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`                                             // Link sent by email, "{token}" is replaced by the token
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Auth_EmailVerification) Reset() {
+	*x = Auth_EmailVerification{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_EmailVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_EmailVerification) ProtoMessage() {}
+
+func (x *Auth_EmailVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_EmailVerification.ProtoReflect.Descriptor instead.
+func (*Auth_EmailVerification) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 3}
+}
+
+func (x *Auth_EmailVerification) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Auth_EmailVerification) GetRestrictedRole() string {
+	if x != nil {
+		return x.RestrictedRole
+	}
+	return ""
+}
+
+func (x *Auth_EmailVerification) GetTokenTtl() *durationpb.Duration {
+	if x != nil {
+		return x.TokenTtl
+	}
+	return nil
+}
+
+func (x *Auth_EmailVerification) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Per-account lockout after failed passwords
+type Auth_Lockout struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaxAttempts     int32                  `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`              // Failed attempts before locking, default 5
+	LockDuration    *durationpb.Duration   `protobuf:"bytes,2,opt,name=lock_duration,json=lockDuration,proto3" json:"lock_duration,omitempty"`            // First lock, doubled on each further failure, default 15m
+	MaxLockDuration *durationpb.Duration   `protobuf:"bytes,3,opt,name=max_lock_duration,json=maxLockDuration,proto3" json:"max_lock_duration,omitempty"` // Upper bound of the lock, default 24h
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Auth_Lockout) Reset() {
+	*x = Auth_Lockout{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_Lockout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_Lockout) ProtoMessage() {}
+
+func (x *Auth_Lockout) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_Lockout.ProtoReflect.Descriptor instead.
+func (*Auth_Lockout) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 4}
+}
+
+func (x *Auth_Lockout) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Auth_Lockout) GetLockDuration() *durationpb.Duration {
+	if x != nil {
+		return x.LockDuration
+	}
+	return nil
+}
+
+func (x *Auth_Lockout) GetMaxLockDuration() *durationpb.Duration {
+	if x != nil {
+		return x.MaxLockDuration
+	}
+	return nil
+}
+
+// API keys of machine clients
+type Auth_APIKeys struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`                           // Start of every key, default "bk"
+	DefaultTtl    *durationpb.Duration   `protobuf:"bytes,2,opt,name=default_ttl,json=defaultTtl,proto3" json:"default_ttl,omitempty"` // Lifetime when the request sets none, default 90 days
+	MaxTtl        *durationpb.Duration   `protobuf:"bytes,3,opt,name=max_ttl,json=maxTtl,proto3" json:"max_ttl,omitempty"`             // Longest allowed lifetime, default 365 days, 0 = unlimited
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_APIKeys) Reset() {
+	*x = Auth_APIKeys{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_APIKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_APIKeys) ProtoMessage() {}
+
+func (x *Auth_APIKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_APIKeys.ProtoReflect.Descriptor instead.
+func (*Auth_APIKeys) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 5}
+}
+
+func (x *Auth_APIKeys) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Auth_APIKeys) GetDefaultTtl() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultTtl
+	}
+	return nil
+}
+
+func (x *Auth_APIKeys) GetMaxTtl() *durationpb.Duration {
+	if x != nil {
+		return x.MaxTtl
+	}
+	return nil
+}
+
+// External OpenID Connect login (authorization code + PKCE)
+type Auth_OIDC struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*Auth_OIDC_Provider  `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	StateTtl      *durationpb.Duration   `protobuf:"bytes,2,opt,name=state_ttl,json=stateTtl,proto3" json:"state_ttl,omitempty"` // Time to complete the login at the provider, default 10m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_OIDC) Reset() {
+	*x = Auth_OIDC{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_OIDC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_OIDC) ProtoMessage() {}
+
+func (x *Auth_OIDC) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_OIDC.ProtoReflect.Descriptor instead.
+func (*Auth_OIDC) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 6}
+}
+
+func (x *Auth_OIDC) GetProviders() []*Auth_OIDC_Provider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *Auth_OIDC) GetStateTtl() *durationpb.Duration {
+	if x != nil {
+		return x.StateTtl
+	}
+	return nil
+}
+
+// Algorithm and cost of new password hashes; older hashes are upgraded on login
+type Auth_PasswordHash struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm         string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                                           // argon2id (default) or bcrypt
+	BcryptCost        int32                  `protobuf:"varint,2,opt,name=bcrypt_cost,json=bcryptCost,proto3" json:"bcrypt_cost,omitempty"`                      // default 12
+	Argon2Memory      uint32                 `protobuf:"varint,3,opt,name=argon2_memory,json=argon2Memory,proto3" json:"argon2_memory,omitempty"`                // KiB, default 19456 (19 MiB)
+	Argon2Iterations  uint32                 `protobuf:"varint,4,opt,name=argon2_iterations,json=argon2Iterations,proto3" json:"argon2_iterations,omitempty"`    // default 2
+	Argon2Parallelism uint32                 `protobuf:"varint,5,opt,name=argon2_parallelism,json=argon2Parallelism,proto3" json:"argon2_parallelism,omitempty"` // default 1, max 255
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Auth_PasswordHash) Reset() {
+	*x = Auth_PasswordHash{}
+	mi := &file_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_PasswordHash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_PasswordHash) ProtoMessage() {}
+
+func (x *Auth_PasswordHash) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_PasswordHash.ProtoReflect.Descriptor instead.
+func (*Auth_PasswordHash) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 7}
+}
+
+func (x *Auth_PasswordHash) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Auth_PasswordHash) GetBcryptCost() int32 {
+	if x != nil {
+		return x.BcryptCost
+	}
+	return 0
+}
+
+func (x *Auth_PasswordHash) GetArgon2Memory() uint32 {
+	if x != nil {
+		return x.Argon2Memory
+	}
+	return 0
+}
+
+func (x *Auth_PasswordHash) GetArgon2Iterations() uint32 {
+	if x != nil {
+		return x.Argon2Iterations
+	}
+	return 0
+}
+
+func (x *Auth_PasswordHash) GetArgon2Parallelism() uint32 {
+	if x != nil {
+		return x.Argon2Parallelism
+	}
+	return 0
+}
+
+// Password reuse and expiry
+type Auth_PasswordPolicy struct {
+	state          protoimpl.MessageState          `protogen:"open.v1"`
+	HistorySize    int32                           `protobuf:"varint,1,opt,name=history_size,json=historySize,proto3" json:"history_size,omitempty"`                                                           // New password must differ from the last N, 0 = off
+	MaxAge         map[string]*durationpb.Duration `protobuf:"bytes,2,rep,name=max_age,json=maxAge,proto3" json:"max_age,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Password lifetime by role, e.g. admin: 7776000s; the shortest of the user's roles applies
+	ChangeTokenTtl *durationpb.Duration            `protobuf:"bytes,3,opt,name=change_token_ttl,json=changeTokenTtl,proto3" json:"change_token_ttl,omitempty"`                                                 // Expired-password change token lifetime, default 10m
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Auth_PasswordPolicy) Reset() {
+	*x = Auth_PasswordPolicy{}
+	mi := &file_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_PasswordPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_PasswordPolicy) ProtoMessage() {}
+
+func (x *Auth_PasswordPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_PasswordPolicy.ProtoReflect.Descriptor instead.
+func (*Auth_PasswordPolicy) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 8}
+}
+
+func (x *Auth_PasswordPolicy) GetHistorySize() int32 {
+	if x != nil {
+		return x.HistorySize
+	}
+	return 0
+}
+
+func (x *Auth_PasswordPolicy) GetMaxAge() map[string]*durationpb.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+func (x *Auth_PasswordPolicy) GetChangeTokenTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ChangeTokenTtl
+	}
+	return nil
+}
+
+// Offline screening of new passwords against the HIBP Pwned Passwords (SHA-1) corpus
+type Auth_BreachedPasswords struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Corpus file, or directory of range files; empty = off
+	// Reject only passwords seen at least this often, default 1. The index keeps 8 bytes
+	// per hash (~7 GB for the full corpus at 1), loading needs 8 bytes per corpus line.
+	MinCount      int64 `protobuf:"varint,2,opt,name=min_count,json=minCount,proto3" json:"min_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_BreachedPasswords) Reset() {
+	*x = Auth_BreachedPasswords{}
+	mi := &file_conf_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_BreachedPasswords) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_BreachedPasswords) ProtoMessage() {}
+
+func (x *Auth_BreachedPasswords) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_BreachedPasswords.ProtoReflect.Descriptor instead.
+func (*Auth_BreachedPasswords) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 9}
+}
+
+func (x *Auth_BreachedPasswords) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Auth_BreachedPasswords) GetMinCount() int64 {
+	if x != nil {
+		return x.MinCount
+	}
+	return 0
+}
+
+// Admin impersonation (AuthService.Impersonate)
+type Auth_Impersonation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenTtl      *durationpb.Duration   `protobuf:"bytes,1,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"` // default 15m, no refresh token is issued
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_Impersonation) Reset() {
+	*x = Auth_Impersonation{}
+	mi := &file_conf_conf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_Impersonation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_Impersonation) ProtoMessage() {}
+
+func (x *Auth_Impersonation) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_Impersonation.ProtoReflect.Descriptor instead.
+func (*Auth_Impersonation) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 10}
+}
+
+func (x *Auth_Impersonation) GetTokenTtl() *durationpb.Duration {
+	if x != nil {
+		return x.TokenTtl
+	}
+	return nil
+}
+
+// RFC 7662 token introspection (/api/v1/auth/introspect), e.g. for the API gateway
+type Auth_Introspection struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Clients       []*Auth_Introspection_Client `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"` // No clients = every request is rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_Introspection) Reset() {
+	*x = Auth_Introspection{}
+	mi := &file_conf_conf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_Introspection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_Introspection) ProtoMessage() {}
+
+func (x *Auth_Introspection) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_Introspection.ProtoReflect.Descriptor instead.
+func (*Auth_Introspection) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 11}
+}
+
+func (x *Auth_Introspection) GetClients() []*Auth_Introspection_Client {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+// Periodic purge of expired and revoked auth_tokens rows
+type Auth_TokenCleanup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`                     // default 1h
+	Retention     *durationpb.Duration   `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`                   // default 720h (30 days) after refresh expiry or revocation, at least refresh_token_expiry
+	BatchSize     int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // rows per statement, default 1000
+	Archive       bool                   `protobuf:"varint,5,opt,name=archive,proto3" json:"archive,omitempty"`                      // move rows to auth_tokens_archive instead of deleting them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_TokenCleanup) Reset() {
+	*x = Auth_TokenCleanup{}
+	mi := &file_conf_conf_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_TokenCleanup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_TokenCleanup) ProtoMessage() {}
+
+func (x *Auth_TokenCleanup) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_TokenCleanup.ProtoReflect.Descriptor instead.
+func (*Auth_TokenCleanup) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 12}
+}
+
+func (x *Auth_TokenCleanup) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Auth_TokenCleanup) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Auth_TokenCleanup) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Auth_TokenCleanup) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Auth_TokenCleanup) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+// Notifications about logins from a new device (browser/OS family + IP subnet)
+type Auth_LoginNotification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Driver         string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`                                       // email (default, sent with the mailer), webhook or none
+	WebhookUrl     string                 `protobuf:"bytes,2,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`             // POST target of the webhook driver
+	WebhookSecret  string                 `protobuf:"bytes,3,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`    // Signs the body: X-Signature: sha256=<hex HMAC-SHA256>
+	WebhookTimeout *durationpb.Duration   `protobuf:"bytes,4,opt,name=webhook_timeout,json=webhookTimeout,proto3" json:"webhook_timeout,omitempty"` // default 10s
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Auth_LoginNotification) Reset() {
+	*x = Auth_LoginNotification{}
+	mi := &file_conf_conf_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_LoginNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_LoginNotification) ProtoMessage() {}
+
+func (x *Auth_LoginNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_LoginNotification.ProtoReflect.Descriptor instead.
+func (*Auth_LoginNotification) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 13}
+}
+
+func (x *Auth_LoginNotification) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Auth_LoginNotification) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *Auth_LoginNotification) GetWebhookSecret() string {
+	if x != nil {
+		return x.WebhookSecret
+	}
+	return ""
+}
+
+func (x *Auth_LoginNotification) GetWebhookTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WebhookTimeout
+	}
+	return nil
+}
+
+type Auth_OIDC_RoleMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClaimValue    string                 `protobuf:"bytes,1,opt,name=claim_value,json=claimValue,proto3" json:"claim_value,omitempty"` // Value of role_claim (string or list element)
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`                               // Our role
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_OIDC_RoleMapping) Reset() {
+	*x = Auth_OIDC_RoleMapping{}
+	mi := &file_conf_conf_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_OIDC_RoleMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_OIDC_RoleMapping) ProtoMessage() {}
+
+func (x *Auth_OIDC_RoleMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_OIDC_RoleMapping.ProtoReflect.Descriptor instead.
+func (*Auth_OIDC_RoleMapping) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 6, 0}
+}
+
+func (x *Auth_OIDC_RoleMapping) GetClaimValue() string {
+	if x != nil {
+		return x.ClaimValue
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_RoleMapping) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type Auth_OIDC_Provider struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Name          string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // Used in URLs: /api/v1/auth/oidc/{name}/...
+	Issuer        string                   `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"` // Issuer URL, metadata is discovered from it
+	ClientId      string                   `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                   `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	RedirectUrl   string                   `protobuf:"bytes,5,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`         // Callback registered at the provider
+	Scopes        []string                 `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`                                      // Default openid, email, profile
+	RoleClaim     string                   `protobuf:"bytes,7,opt,name=role_claim,json=roleClaim,proto3" json:"role_claim,omitempty"`               // ID token claim mapped to a role, e.g. "groups"
+	RoleMappings  []*Auth_OIDC_RoleMapping `protobuf:"bytes,8,rep,name=role_mappings,json=roleMappings,proto3" json:"role_mappings,omitempty"`      // First match wins
+	DefaultRole   string                   `protobuf:"bytes,9,opt,name=default_role,json=defaultRole,proto3" json:"default_role,omitempty"`         // Role of provisioned users without a match, default "user"
+	AutoProvision bool                     `protobuf:"varint,10,opt,name=auto_provision,json=autoProvision,proto3" json:"auto_provision,omitempty"` // Create unknown users on first login
+	LinkByEmail   bool                     `protobuf:"varint,11,opt,name=link_by_email,json=linkByEmail,proto3" json:"link_by_email,omitempty"`     // Link existing users by verified email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_OIDC_Provider) Reset() {
+	*x = Auth_OIDC_Provider{}
+	mi := &file_conf_conf_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_OIDC_Provider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_OIDC_Provider) ProtoMessage() {}
+
+func (x *Auth_OIDC_Provider) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_OIDC_Provider.ProtoReflect.Descriptor instead.
+func (*Auth_OIDC_Provider) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 6, 1}
+}
+
+func (x *Auth_OIDC_Provider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *Auth_OIDC_Provider) GetRoleClaim() string {
+	if x != nil {
+		return x.RoleClaim
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetRoleMappings() []*Auth_OIDC_RoleMapping {
+	if x != nil {
+		return x.RoleMappings
+	}
+	return nil
+}
+
+func (x *Auth_OIDC_Provider) GetDefaultRole() string {
+	if x != nil {
+		return x.DefaultRole
+	}
+	return ""
+}
+
+func (x *Auth_OIDC_Provider) GetAutoProvision() bool {
+	if x != nil {
+		return x.AutoProvision
+	}
+	return false
+}
+
+func (x *Auth_OIDC_Provider) GetLinkByEmail() bool {
+	if x != nil {
+		return x.LinkByEmail
+	}
+	return false
+}
+
+type Auth_Introspection_Client struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auth_Introspection_Client) Reset() {
+	*x = Auth_Introspection_Client{}
+	mi := &file_conf_conf_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auth_Introspection_Client) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auth_Introspection_Client) ProtoMessage() {}
+
+func (x *Auth_Introspection_Client) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auth_Introspection_Client.ProtoReflect.Descriptor instead.
+func (*Auth_Introspection_Client) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 11, 0}
+}
+
+func (x *Auth_Introspection_Client) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Auth_Introspection_Client) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+type Mail_SMTP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mail_SMTP) Reset() {
+	*x = Mail_SMTP{}
+	mi := &file_conf_conf_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mail_SMTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mail_SMTP) ProtoMessage() {}
+
+func (x *Mail_SMTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mail_SMTP.ProtoReflect.Descriptor instead.
+func (*Mail_SMTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Mail_SMTP) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Mail_SMTP) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Mail_SMTP) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Mail_SMTP) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+var File_conf_conf_proto protoreflect.FileDescriptor
+
+const file_conf_conf_proto_rawDesc = "" +
+	"\n" +
+	"\x0fconf/conf.proto\x12\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xa9\x01\n" +
+	"\tBootstrap\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12$\n" +
+	"\x04auth\x18\x03 \x01(\v2\x10.kratos.api.AuthR\x04auth\x12$\n" +
+	"\x04mail\x18\x04 \x01(\v2\x10.kratos.api.MailR\x04mail\"\xb8\x02\n" +
+	"\x06Server\x12+\n" +
+	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
+	"\x04HTTP\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1ai\n" +
+	"\x04GRPC\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\x95\x05\n" +
+	"\x04Data\x125\n" +
+	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12B\n" +
+	"\rread_database\x18\x02 \x01(\v2\x1d.kratos.api.Data.ReadDatabaseR\freadDatabase\x12E\n" +
+	"\x0ewrite_database\x18\x03 \x01(\v2\x1e.kratos.api.Data.WriteDatabaseR\rwriteDatabase\x12,\n" +
+	"\x05redis\x18\x04 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
+	"\bDatabase\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x1a>\n" +
+	"\fReadDatabase\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x1a?\n" +
+	"\rWriteDatabase\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xdf\x01\n" +
+	"\x05Redis\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
+	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
+	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x0e\n" +
+	"\x02db\x18\x06 \x01(\x05R\x02db\"\xe3\x1e\n" +
+	"\x04Auth\x12\x1d\n" +
+	"\n" +
+	"jwt_secret\x18\x01 \x01(\tR\tjwtSecret\x12.\n" +
+	"\x13access_token_expiry\x18\x02 \x01(\x03R\x11accessTokenExpiry\x120\n" +
+	"\x14refresh_token_expiry\x18\x03 \x01(\x03R\x12refreshTokenExpiry\x12!\n" +
+	"\ftoken_pepper\x18\x04 \x01(\tR\vtokenPepper\x12\"\n" +
+	"\ractive_key_id\x18\x05 \x01(\tR\vactiveKeyId\x12>\n" +
+	"\fsigning_keys\x18\x06 \x03(\v2\x1b.kratos.api.Auth.SigningKeyR\vsigningKeys\x12K\n" +
+	"\x14revocation_cache_ttl\x18\a \x01(\v2\x19.google.protobuf.DurationR\x12revocationCacheTtl\x122\n" +
+	"\x15revocation_cache_size\x18\b \x01(\x05R\x13revocationCacheSize\x12&\n" +
+	"\x03mfa\x18\t \x01(\v2\x14.kratos.api.Auth.MFAR\x03mfa\x12E\n" +
+	"\x0epassword_reset\x18\n" +
+	" \x01(\v2\x1e.kratos.api.Auth.PasswordResetR\rpasswordReset\x12Q\n" +
+	"\x12email_verification\x18\v \x01(\v2\".kratos.api.Auth.EmailVerificationR\x11emailVerification\x122\n" +
+	"\alockout\x18\f \x01(\v2\x18.kratos.api.Auth.LockoutR\alockout\x123\n" +
+	"\bapi_keys\x18\r \x01(\v2\x18.kratos.api.Auth.APIKeysR\aapiKeys\x12)\n" +
+	"\x04oidc\x18\x0e \x01(\v2\x15.kratos.api.Auth.OIDCR\x04oidc\x12B\n" +
+	"\rpassword_hash\x18\x0f \x01(\v2\x1d.kratos.api.Auth.PasswordHashR\fpasswordHash\x12H\n" +
+	"\x0fpassword_policy\x18\x10 \x01(\v2\x1f.kratos.api.Auth.PasswordPolicyR\x0epasswordPolicy\x12Q\n" +
+	"\x12breached_passwords\x18\x11 \x01(\v2\".kratos.api.Auth.BreachedPasswordsR\x11breachedPasswords\x12D\n" +
+	"\rimpersonation\x18\x12 \x01(\v2\x1e.kratos.api.Auth.ImpersonationR\rimpersonation\x12D\n" +
+	"\rintrospection\x18\x13 \x01(\v2\x1e.kratos.api.Auth.IntrospectionR\rintrospection\x12B\n" +
+	"\rtoken_cleanup\x18\x14 \x01(\v2\x1d.kratos.api.Auth.TokenCleanupR\ftokenCleanup\x12Q\n" +
+	"\x12login_notification\x18\x15 \x01(\v2\".kratos.api.Auth.LoginNotificationR\x11loginNotification\x1a\x8e\x01\n" +
+	"\n" +
+	"SigningKey\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12(\n" +
+	"\x10private_key_file\x18\x03 \x01(\tR\x0eprivateKeyFile\x12&\n" +
+	"\x0fpublic_key_file\x18\x04 \x01(\tR\rpublicKeyFile\x1a\xb2\x01\n" +
+	"\x03MFA\x12\x16\n" +
+	"\x06issuer\x18\x01 \x01(\tR\x06issuer\x12,\n" +
+	"\x12require_for_admins\x18\x02 \x01(\bR\x10requireForAdmins\x12>\n" +
+	"\rchallenge_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fchallengeTtl\x12%\n" +
+	"\x0eencryption_key\x18\x04 \x01(\tR\rencryptionKey\x1aY\n" +
+	"\rPasswordReset\x126\n" +
+	"\ttoken_ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\btokenTtl\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x1a\x9a\x01\n" +
+	"\x11EmailVerification\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12'\n" +
+	"\x0frestricted_role\x18\x02 \x01(\tR\x0erestrictedRole\x126\n" +
+	"\ttoken_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\btokenTtl\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x1a\xb3\x01\n" +
+	"\aLockout\x12!\n" +
+	"\fmax_attempts\x18\x01 \x01(\x05R\vmaxAttempts\x12>\n" +
+	"\rlock_duration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\flockDuration\x12E\n" +
+	"\x11max_lock_duration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0fmaxLockDuration\x1a\x91\x01\n" +
+	"\aAPIKeys\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12:\n" +
+	"\vdefault_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"defaultTtl\x122\n" +
+	"\amax_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06maxTtl\x1a\xcb\x04\n" +
+	"\x04OIDC\x12<\n" +
+	"\tproviders\x18\x01 \x03(\v2\x1e.kratos.api.Auth.OIDC.ProviderR\tproviders\x126\n" +
+	"\tstate_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bstateTtl\x1aB\n" +
+	"\vRoleMapping\x12\x1f\n" +
+	"\vclaim_value\x18\x01 \x01(\tR\n" +
+	"claimValue\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x1a\x88\x03\n" +
+	"\bProvider\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\x12!\n" +
+	"\fredirect_url\x18\x05 \x01(\tR\vredirectUrl\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"role_claim\x18\a \x01(\tR\troleClaim\x12F\n" +
+	"\rrole_mappings\x18\b \x03(\v2!.kratos.api.Auth.OIDC.RoleMappingR\froleMappings\x12!\n" +
+	"\fdefault_role\x18\t \x01(\tR\vdefaultRole\x12%\n" +
+	"\x0eauto_provision\x18\n" +
+	" \x01(\bR\rautoProvision\x12\"\n" +
+	"\rlink_by_email\x18\v \x01(\bR\vlinkByEmail\x1a\xce\x01\n" +
+	"\fPasswordHash\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x1f\n" +
+	"\vbcrypt_cost\x18\x02 \x01(\x05R\n" +
+	"bcryptCost\x12#\n" +
+	"\rargon2_memory\x18\x03 \x01(\rR\fargon2Memory\x12+\n" +
+	"\x11argon2_iterations\x18\x04 \x01(\rR\x10argon2Iterations\x12-\n" +
+	"\x12argon2_parallelism\x18\x05 \x01(\rR\x11argon2Parallelism\x1a\x94\x02\n" +
+	"\x0ePasswordPolicy\x12!\n" +
+	"\fhistory_size\x18\x01 \x01(\x05R\vhistorySize\x12D\n" +
+	"\amax_age\x18\x02 \x03(\v2+.kratos.api.Auth.PasswordPolicy.MaxAgeEntryR\x06maxAge\x12C\n" +
+	"\x10change_token_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0echangeTokenTtl\x1aT\n" +
+	"\vMaxAgeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x05value:\x028\x01\x1aD\n" +
+	"\x11BreachedPasswords\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\tmin_count\x18\x02 \x01(\x03R\bminCount\x1aG\n" +
+	"\rImpersonation\x126\n" +
+	"\ttoken_ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\btokenTtl\x1a\x9c\x01\n" +
+	"\rIntrospection\x12?\n" +
+	"\aclients\x18\x01 \x03(\v2%.kratos.api.Auth.Introspection.ClientR\aclients\x1aJ\n" +
+	"\x06Client\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\x1a\xd1\x01\n" +
+	"\fTokenCleanup\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\x127\n" +
+	"\tretention\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tretention\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\x12\x18\n" +
+	"\aarchive\x18\x05 \x01(\bR\aarchive\x1a\xb7\x01\n" +
+	"\x11LoginNotification\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x1f\n" +
+	"\vwebhook_url\x18\x02 \x01(\tR\n" +
+	"webhookUrl\x12%\n" +
+	"\x0ewebhook_secret\x18\x03 \x01(\tR\rwebhookSecret\x12B\n" +
+	"\x0fwebhook_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0ewebhookTimeout\"\xe4\x01\n" +
+	"\x04Mail\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12)\n" +
+	"\x04smtp\x18\x03 \x01(\v2\x15.kratos.api.Mail.SMTPR\x04smtp\x12\x1d\n" +
+	"\n" +
+	"outbox_dir\x18\x04 \x01(\tR\toutboxDir\x1af\n" +
+	"\x04SMTP\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpasswordB7Z5github.com/go-kratos/kratos-layout/internal/conf;confb\x06proto3"
 
 var (
 	file_conf_conf_proto_rawDescOnce sync.Once
@@ -664,39 +2180,94 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_conf_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Server)(nil),              // 1: kratos.api.Server
-	(*Data)(nil),                // 2: kratos.api.Data
-	(*Auth)(nil),                // 3: kratos.api.Auth
-	(*Server_HTTP)(nil),         // 4: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 5: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 6: kratos.api.Data.Database
-	(*Data_ReadDatabase)(nil),   // 7: kratos.api.Data.ReadDatabase
-	(*Data_WriteDatabase)(nil),  // 8: kratos.api.Data.WriteDatabase
-	(*Data_Redis)(nil),          // 9: kratos.api.Data.Redis
-	(*durationpb.Duration)(nil), // 10: google.protobuf.Duration
+	(*Bootstrap)(nil),                 // 0: kratos.api.Bootstrap
+	(*Server)(nil),                    // 1: kratos.api.Server
+	(*Data)(nil),                      // 2: kratos.api.Data
+	(*Auth)(nil),                      // 3: kratos.api.Auth
+	(*Mail)(nil),                      // 4: kratos.api.Mail
+	(*Server_HTTP)(nil),               // 5: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),               // 6: kratos.api.Server.GRPC
+	(*Data_Database)(nil),             // 7: kratos.api.Data.Database
+	(*Data_ReadDatabase)(nil),         // 8: kratos.api.Data.ReadDatabase
+	(*Data_WriteDatabase)(nil),        // 9: kratos.api.Data.WriteDatabase
+	(*Data_Redis)(nil),                // 10: kratos.api.Data.Redis
+	(*Auth_SigningKey)(nil),           // 11: kratos.api.Auth.SigningKey
+	(*Auth_MFA)(nil),                  // 12: kratos.api.Auth.MFA
+	(*Auth_PasswordReset)(nil),        // 13: kratos.api.Auth.PasswordReset
+	(*Auth_EmailVerification)(nil),    // 14: kratos.api.Auth.EmailVerification
+	(*Auth_Lockout)(nil),              // 15: kratos.api.Auth.Lockout
+	(*Auth_APIKeys)(nil),              // 16: kratos.api.Auth.APIKeys
+	(*Auth_OIDC)(nil),                 // 17: kratos.api.Auth.OIDC
+	(*Auth_PasswordHash)(nil),         // 18: kratos.api.Auth.PasswordHash
+	(*Auth_PasswordPolicy)(nil),       // 19: kratos.api.Auth.PasswordPolicy
+	(*Auth_BreachedPasswords)(nil),    // 20: kratos.api.Auth.BreachedPasswords
+	(*Auth_Impersonation)(nil),        // 21: kratos.api.Auth.Impersonation
+	(*Auth_Introspection)(nil),        // 22: kratos.api.Auth.Introspection
+	(*Auth_TokenCleanup)(nil),         // 23: kratos.api.Auth.TokenCleanup
+	(*Auth_LoginNotification)(nil),    // 24: kratos.api.Auth.LoginNotification
+	(*Auth_OIDC_RoleMapping)(nil),     // 25: kratos.api.Auth.OIDC.RoleMapping
+	(*Auth_OIDC_Provider)(nil),        // 26: kratos.api.Auth.OIDC.Provider
+	nil,                               // 27: kratos.api.Auth.PasswordPolicy.MaxAgeEntry
+	(*Auth_Introspection_Client)(nil), // 28: kratos.api.Auth.Introspection.Client
+	(*Mail_SMTP)(nil),                 // 29: kratos.api.Mail.SMTP
+	(*durationpb.Duration)(nil),       // 30: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
 	3,  // 2: kratos.api.Bootstrap.auth:type_name -> kratos.api.Auth
-	4,  // 3: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	5,  // 4: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	6,  // 5: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	7,  // 6: kratos.api.Data.read_database:type_name -> kratos.api.Data.ReadDatabase
-	8,  // 7: kratos.api.Data.write_database:type_name -> kratos.api.Data.WriteDatabase
-	9,  // 8: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	10, // 9: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	10, // 10: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	10, // 11: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	10, // 12: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	4,  // 3: kratos.api.Bootstrap.mail:type_name -> kratos.api.Mail
+	5,  // 4: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	6,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	7,  // 6: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	8,  // 7: kratos.api.Data.read_database:type_name -> kratos.api.Data.ReadDatabase
+	9,  // 8: kratos.api.Data.write_database:type_name -> kratos.api.Data.WriteDatabase
+	10, // 9: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	11, // 10: kratos.api.Auth.signing_keys:type_name -> kratos.api.Auth.SigningKey
+	30, // 11: kratos.api.Auth.revocation_cache_ttl:type_name -> google.protobuf.Duration
+	12, // 12: kratos.api.Auth.mfa:type_name -> kratos.api.Auth.MFA
+	13, // 13: kratos.api.Auth.password_reset:type_name -> kratos.api.Auth.PasswordReset
+	14, // 14: kratos.api.Auth.email_verification:type_name -> kratos.api.Auth.EmailVerification
+	15, // 15: kratos.api.Auth.lockout:type_name -> kratos.api.Auth.Lockout
+	16, // 16: kratos.api.Auth.api_keys:type_name -> kratos.api.Auth.APIKeys
+	17, // 17: kratos.api.Auth.oidc:type_name -> kratos.api.Auth.OIDC
+	18, // 18: kratos.api.Auth.password_hash:type_name -> kratos.api.Auth.PasswordHash
+	19, // 19: kratos.api.Auth.password_policy:type_name -> kratos.api.Auth.PasswordPolicy
+	20, // 20: kratos.api.Auth.breached_passwords:type_name -> kratos.api.Auth.BreachedPasswords
+	21, // 21: kratos.api.Auth.impersonation:type_name -> kratos.api.Auth.Impersonation
+	22, // 22: kratos.api.Auth.introspection:type_name -> kratos.api.Auth.Introspection
+	23, // 23: kratos.api.Auth.token_cleanup:type_name -> kratos.api.Auth.TokenCleanup
+	24, // 24: kratos.api.Auth.login_notification:type_name -> kratos.api.Auth.LoginNotification
+	29, // 25: kratos.api.Mail.smtp:type_name -> kratos.api.Mail.SMTP
+	30, // 26: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	30, // 27: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	30, // 28: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	30, // 29: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	30, // 30: kratos.api.Auth.MFA.challenge_ttl:type_name -> google.protobuf.Duration
+	30, // 31: kratos.api.Auth.PasswordReset.token_ttl:type_name -> google.protobuf.Duration
+	30, // 32: kratos.api.Auth.EmailVerification.token_ttl:type_name -> google.protobuf.Duration
+	30, // 33: kratos.api.Auth.Lockout.lock_duration:type_name -> google.protobuf.Duration
+	30, // 34: kratos.api.Auth.Lockout.max_lock_duration:type_name -> google.protobuf.Duration
+	30, // 35: kratos.api.Auth.APIKeys.default_ttl:type_name -> google.protobuf.Duration
+	30, // 36: kratos.api.Auth.APIKeys.max_ttl:type_name -> google.protobuf.Duration
+	26, // 37: kratos.api.Auth.OIDC.providers:type_name -> kratos.api.Auth.OIDC.Provider
+	30, // 38: kratos.api.Auth.OIDC.state_ttl:type_name -> google.protobuf.Duration
+	27, // 39: kratos.api.Auth.PasswordPolicy.max_age:type_name -> kratos.api.Auth.PasswordPolicy.MaxAgeEntry
+	30, // 40: kratos.api.Auth.PasswordPolicy.change_token_ttl:type_name -> google.protobuf.Duration
+	30, // 41: kratos.api.Auth.Impersonation.token_ttl:type_name -> google.protobuf.Duration
+	28, // 42: kratos.api.Auth.Introspection.clients:type_name -> kratos.api.Auth.Introspection.Client
+	30, // 43: kratos.api.Auth.TokenCleanup.interval:type_name -> google.protobuf.Duration
+	30, // 44: kratos.api.Auth.TokenCleanup.retention:type_name -> google.protobuf.Duration
+	30, // 45: kratos.api.Auth.LoginNotification.webhook_timeout:type_name -> google.protobuf.Duration
+	25, // 46: kratos.api.Auth.OIDC.Provider.role_mappings:type_name -> kratos.api.Auth.OIDC.RoleMapping
+	30, // 47: kratos.api.Auth.PasswordPolicy.MaxAgeEntry.value:type_name -> google.protobuf.Duration
+	48, // [48:48] is the sub-list for method output_type
+	48, // [48:48] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -710,7 +2281,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type apiKeyCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewAPIKeyCommandRepo(data *Data, logger log.Logger) biz.APIKeyCommandRepo {
+	return &apiKeyCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *apiKeyCommandRepo) SaveAPIKey(ctx context.Context, key *biz.APIKey) (*biz.APIKey, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(key).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save API key: %v", err)
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyCommandRepo) RevokeAPIKey(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	result := db.WithContext(ctx).Model(&biz.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked = ?", id, userID, false).
+		Updates(map[string]interface{}{
+			"revoked":    true,
+			"revoked_at": &now,
+			"updated_by": userID,
+		})
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to revoke API key: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *apiKeyCommandRepo) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	db := r.data.GetWriteDB()
+	// UpdateColumn: a bookkeeping write must not bump updated_at/version
+	if err := db.WithContext(ctx).Model(&biz.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", &usedAt).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to update API key last use: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type apiKeyQueryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewAPIKeyQueryRepo(data *Data, logger log.Logger) biz.APIKeyQueryRepo {
+	return &apiKeyQueryRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *apiKeyQueryRepo) FindAPIKeyByHash(ctx context.Context, keyHash string) (*biz.APIKey, error) {
+	db := r.data.GetReadDB()
+	var key biz.APIKey
+
+	if err := db.WithContext(ctx).
+		Where("key_hash = ?", keyHash).
+		First(&key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.WithContext(ctx).Errorf("Failed to find API key: %v", err)
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (r *apiKeyQueryRepo) ListUserAPIKeys(ctx context.Context, userID uuid.UUID) ([]*biz.APIKey, error) {
+	db := r.data.GetReadDB()
+	var keys []*biz.APIKey
+
+	if err := db.WithContext(ctx).
+		Where("user_id = ? AND revoked = ?", userID, false).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list API keys: %v", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
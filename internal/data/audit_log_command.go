@@ -0,0 +1,30 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type auditLogCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewAuditLogCommandRepo(data *Data, logger log.Logger) biz.AuditLogCommandRepo {
+	return &auditLogCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *auditLogCommandRepo) SaveAuditLog(ctx context.Context, entry *biz.AuditLog) (*biz.AuditLog, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(entry).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save audit log: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gofrs/uuid/v5"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
 )
 
 type authCommandRepo struct {
@@ -41,12 +42,12 @@ func (r *authCommandRepo) SaveToken(ctx context.Context, token *biz.AuthToken) (
 	return token, nil
 }
 
-func (r *authCommandRepo) RevokeToken(ctx context.Context, refreshToken string) error {
+func (r *authCommandRepo) RevokeToken(ctx context.Context, refreshTokenHash string) error {
 	db := r.data.GetWriteDB()
 	now := time.Now()
 	
 	result := db.WithContext(ctx).Model(&biz.AuthToken{}).
-		Where("refresh_token = ? AND revoked = ?", refreshToken, false).
+		Where("refresh_token_hash = ? AND revoked = ?", refreshTokenHash, false).
 		Updates(map[string]interface{}{
 			"revoked":    true,
 			"revoked_at": &now,
@@ -58,7 +59,7 @@ func (r *authCommandRepo) RevokeToken(ctx context.Context, refreshToken string)
 	}
 	
 	if result.RowsAffected == 0 {
-		r.log.WithContext(ctx).Warnf("Token not found or already revoked: %s", refreshToken)
+		r.log.WithContext(ctx).Warn("Token not found or already revoked")
 	}
 	
 	return nil
@@ -85,3 +86,58 @@ func (r *authCommandRepo) RevokeAllUserTokens(ctx context.Context, userID uuid.U
 	return nil
 }
 
+func (r *authCommandRepo) RotateToken(ctx context.Context, oldTokenID uuid.UUID, newToken *biz.AuthToken) (*biz.AuthToken, error) {
+	db := r.data.GetWriteDB()
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newToken).Error; err != nil {
+			return err
+		}
+
+		// Only an active token can be rotated; a concurrent rotation wins the race
+		now := time.Now()
+		result := tx.Model(&biz.AuthToken{}).
+			Where("id = ? AND revoked = ?", oldTokenID, false).
+			Updates(map[string]interface{}{
+				"revoked":        true,
+				"revoked_at":     &now,
+				"replaced_by_id": newToken.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return biz.ErrTokenReused
+		}
+		return nil
+	})
+	if err != nil {
+		if err != biz.ErrTokenReused {
+			r.log.WithContext(ctx).Errorf("Failed to rotate token: %v", err)
+		}
+		return nil, err
+	}
+
+	return newToken, nil
+}
+
+func (r *authCommandRepo) RevokeTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	result := db.WithContext(ctx).Model(&biz.AuthToken{}).
+		Where("family_id = ? AND revoked = ?", familyID, false).
+		Updates(map[string]interface{}{
+			"revoked":    true,
+			"revoked_at": &now,
+		})
+
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to revoke token family: %v", result.Error)
+		return result.Error
+	}
+
+	r.log.WithContext(ctx).Infof("Revoked %d tokens in family %s", result.RowsAffected, familyID.String())
+
+	return nil
+}
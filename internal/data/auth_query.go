@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kratos/kratos-layout/internal/biz"
 	"github.com/gofrs/uuid/v5"
@@ -22,12 +23,12 @@ func NewAuthQueryRepo(data *Data, logger log.Logger) biz.AuthQueryRepo {
 	}
 }
 
-func (r *authQueryRepo) FindTokenByRefreshToken(ctx context.Context, refreshToken string) (*biz.AuthToken, error) {
+func (r *authQueryRepo) FindTokenByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*biz.AuthToken, error) {
 	db := r.data.GetReadDB()
 	var token biz.AuthToken
 	
 	if err := db.WithContext(ctx).
-		Where("refresh_token = ?", refreshToken).
+		Where("refresh_token_hash = ?", refreshTokenHash).
 		First(&token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -39,12 +40,12 @@ func (r *authQueryRepo) FindTokenByRefreshToken(ctx context.Context, refreshToke
 	return &token, nil
 }
 
-func (r *authQueryRepo) FindTokenByAccessToken(ctx context.Context, accessToken string) (*biz.AuthToken, error) {
+func (r *authQueryRepo) FindTokenByAccessTokenHash(ctx context.Context, accessTokenHash string) (*biz.AuthToken, error) {
 	db := r.data.GetReadDB()
 	var token biz.AuthToken
 	
 	if err := db.WithContext(ctx).
-		Where("token = ? AND revoked = ?", accessToken, false).
+		Where("token_hash = ? AND revoked = ?", accessTokenHash, false).
 		First(&token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -71,3 +72,55 @@ func (r *authQueryRepo) ListUserTokens(ctx context.Context, userID uuid.UUID) ([
 	return tokens, nil
 }
 
+
+func (r *authQueryRepo) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*biz.Session, error) {
+	db := r.data.GetReadDB()
+	var rows []struct {
+		FamilyID         uuid.UUID
+		IPAddress        string
+		UserAgent        string
+		LastUsedAt       *time.Time
+		RefreshExpiresAt time.Time
+		SessionCreatedAt time.Time
+	}
+
+	// One active token per family; the session starts with the first token of the family
+	if err := db.WithContext(ctx).Model(&biz.AuthToken{}).
+		Select("auth_tokens.family_id, auth_tokens.ip_address, auth_tokens.user_agent, auth_tokens.last_used_at, auth_tokens.refresh_expires_at, " +
+			"(SELECT MIN(f.created_at) FROM auth_tokens f WHERE f.family_id = auth_tokens.family_id) AS session_created_at").
+		Where("auth_tokens.user_id = ? AND auth_tokens.revoked = ? AND auth_tokens.refresh_expires_at > ?", userID, false, time.Now()).
+		Order("session_created_at DESC").
+		Scan(&rows).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list user sessions: %v", err)
+		return nil, err
+	}
+
+	sessions := make([]*biz.Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, &biz.Session{
+			ID:         row.FamilyID,
+			UserID:     userID,
+			IPAddress:  row.IPAddress,
+			UserAgent:  row.UserAgent,
+			CreatedAt:  row.SessionCreatedAt,
+			LastUsedAt: row.LastUsedAt,
+			ExpiresAt:  row.RefreshExpiresAt,
+		})
+	}
+	return sessions, nil
+}
+
+func (r *authQueryRepo) IsSessionActive(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	db := r.data.GetReadDB()
+	var count int64
+
+	if err := db.WithContext(ctx).Model(&biz.AuthToken{}).
+		Where("family_id = ? AND revoked = ? AND refresh_expires_at > ?", familyID, false, time.Now()).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to check session: %v", err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
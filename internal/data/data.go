@@ -1,10 +1,13 @@
 package data
 
 import (
+	"context"
+
 	"github.com/go-kratos/kratos-layout/internal/conf"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -18,6 +21,24 @@ var ProviderSet = wire.NewSet(
 	NewUserQueryRepo,
 	NewAuthCommandRepo,
 	NewAuthQueryRepo,
+	NewSessionRevocationRepo,
+	NewMFACommandRepo,
+	NewMFAQueryRepo,
+	NewPasswordResetCommandRepo,
+	NewEmailVerificationCommandRepo,
+	NewAuditLogCommandRepo,
+	NewTokenCleanupRepo,
+	NewAPIKeyCommandRepo,
+	NewAPIKeyQueryRepo,
+	NewOIDCCommandRepo,
+	NewOIDCQueryRepo,
+	NewMailer,
+	NewLoginHistoryCommandRepo,
+	NewLoginHistoryQueryRepo,
+	NewLoginNotifier,
+	NewRoleCommandRepo,
+	NewRoleQueryRepo,
+	NewTenantQueryRepo,
 	NewCountryCommandRepo,
 	NewCountryQueryRepo,
 	NewProvinceCommandRepo,
@@ -28,8 +49,9 @@ var ProviderSet = wire.NewSet(
 
 // Data chứa cả read và write database
 type Data struct {
-	readDB  *gorm.DB // Database cho read operations
-	writeDB *gorm.DB // Database cho write operations
+	readDB  *gorm.DB      // Database cho read operations
+	writeDB *gorm.DB      // Database cho write operations
+	rdb     *redis.Client // Redis, nil nếu không cấu hình
 }
 
 // NewData tạo connections cho cả read và write database
@@ -72,9 +94,35 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 		return nil, nil, err
 	}
 
+	// Scope every repository to the tenant of the request context
+	for _, db := range []*gorm.DB{writeDB, readDB} {
+		if err := registerTenantScope(db); err != nil {
+			logHelper.Errorf("Failed to register tenant scope: %v", err)
+			return nil, nil, err
+		}
+	}
+
 	logHelper.Info("Write database connection established successfully")
 	logHelper.Info("Read database connection established successfully")
 
+	// Kết nối Redis (optional)
+	var rdb *redis.Client
+	if c.Redis != nil && c.Redis.Addr != "" {
+		rdb = redis.NewClient(&redis.Options{
+			Network:      c.Redis.Network,
+			Addr:         c.Redis.Addr,
+			Password:     c.Redis.Password,
+			DB:           int(c.Redis.Db),
+			ReadTimeout:  c.Redis.ReadTimeout.AsDuration(),
+			WriteTimeout: c.Redis.WriteTimeout.AsDuration(),
+		})
+		if err := rdb.Ping(context.Background()).Err(); err != nil {
+			logHelper.Errorf("Failed to ping redis: %v", err)
+			return nil, nil, err
+		}
+		logHelper.Info("Redis connection established successfully")
+	}
+
 	cleanup := func() {
 		logHelper.Info("closing the data resources")
 
@@ -97,11 +145,21 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 				logHelper.Info("Read database connection closed")
 			}
 		}
+
+		// Close redis
+		if rdb != nil {
+			if err := rdb.Close(); err != nil {
+				logHelper.Errorf("Failed to close redis: %v", err)
+			} else {
+				logHelper.Info("Redis connection closed")
+			}
+		}
 	}
 
 	return &Data{
 		readDB:  readDB,
 		writeDB: writeDB,
+		rdb:     rdb,
 	}, cleanup, nil
 }
 
@@ -114,3 +172,8 @@ func (d *Data) GetReadDB() *gorm.DB {
 func (d *Data) GetWriteDB() *gorm.DB {
 	return d.writeDB
 }
+
+// GetRedis returns the redis client, or nil when redis is not configured
+func (d *Data) GetRedis() *redis.Client {
+	return d.rdb
+}
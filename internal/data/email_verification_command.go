@@ -0,0 +1,67 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm/clause"
+)
+
+type emailVerificationCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewEmailVerificationCommandRepo(data *Data, logger log.Logger) biz.EmailVerificationCommandRepo {
+	return &emailVerificationCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *emailVerificationCommandRepo) SaveVerificationToken(ctx context.Context, token *biz.EmailVerificationToken) (*biz.EmailVerificationToken, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(token).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save verification token: %v", err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *emailVerificationCommandRepo) UseVerificationToken(ctx context.Context, tokenHash string) (*biz.EmailVerificationToken, error) {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	// Single statement so a token can only be used once, even by concurrent requests
+	var tokens []*biz.EmailVerificationToken
+	result := db.WithContext(ctx).Model(&tokens).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		Update("used_at", &now)
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to use verification token: %v", result.Error)
+		return nil, result.Error
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return tokens[0], nil
+}
+
+func (r *emailVerificationCommandRepo) InvalidateUserVerificationTokens(ctx context.Context, userID uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	if err := db.WithContext(ctx).Model(&biz.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", &now).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to invalidate verification tokens: %v", err)
+		return err
+	}
+	return nil
+}
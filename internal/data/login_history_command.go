@@ -0,0 +1,30 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type loginHistoryCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewLoginHistoryCommandRepo(data *Data, logger log.Logger) biz.LoginHistoryCommandRepo {
+	return &loginHistoryCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *loginHistoryCommandRepo) SaveLoginHistory(ctx context.Context, entry *biz.LoginHistory) (*biz.LoginHistory, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(entry).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save login history: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
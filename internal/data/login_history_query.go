@@ -0,0 +1,53 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type loginHistoryQueryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewLoginHistoryQueryRepo(data *Data, logger log.Logger) biz.LoginHistoryQueryRepo {
+	return &loginHistoryQueryRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *loginHistoryQueryRepo) ListUserLoginHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*biz.LoginHistory, error) {
+	db := r.data.GetReadDB()
+	var entries []*biz.LoginHistory
+
+	if err := db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list login history: %v", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *loginHistoryQueryRepo) ListKnownDevices(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	db := r.data.GetReadDB()
+	var fingerprints []string
+
+	if err := db.WithContext(ctx).Model(&biz.LoginHistory{}).
+		Where("user_id = ? AND success = ?", userID, true).
+		Distinct().
+		Pluck("device_fingerprint", &fingerprints).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list known devices: %v", err)
+		return nil, err
+	}
+
+	return fingerprints, nil
+}
@@ -0,0 +1,145 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	LoginNotifierEmail   = "email"
+	LoginNotifierWebhook = "webhook"
+	LoginNotifierNone    = "none"
+
+	defaultWebhookTimeout = 10 * time.Second
+
+	EventLoginNewDevice = "login.new_device"
+)
+
+// NewLoginNotifier creates the notifier selected by auth.login_notification.driver (email by default).
+// The none driver returns nil, which disables notifications.
+func NewLoginNotifier(c *conf.Auth, mailer biz.Mailer, logger log.Logger) (biz.LoginNotifier, error) {
+	var nc *conf.Auth_LoginNotification
+	if c != nil {
+		nc = c.LoginNotification
+	}
+	driver := LoginNotifierEmail
+	if nc != nil && nc.Driver != "" {
+		driver = nc.Driver
+	}
+
+	switch driver {
+	case LoginNotifierEmail:
+		return &emailLoginNotifier{
+			mailer: mailer,
+		}, nil
+	case LoginNotifierWebhook:
+		if nc.WebhookUrl == "" {
+			return nil, fmt.Errorf("login notification: webhook driver requires auth.login_notification.webhook_url")
+		}
+		timeout := defaultWebhookTimeout
+		if nc.WebhookTimeout != nil && nc.WebhookTimeout.AsDuration() > 0 {
+			timeout = nc.WebhookTimeout.AsDuration()
+		}
+		return &webhookLoginNotifier{
+			url:    nc.WebhookUrl,
+			secret: []byte(nc.WebhookSecret),
+			client: &http.Client{Timeout: timeout},
+			log:    log.NewHelper(logger),
+		}, nil
+	case LoginNotifierNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("login notification: unknown driver %q", driver)
+	}
+}
+
+// emailLoginNotifier emails the user through the configured mailer (SMTP or outbox)
+type emailLoginNotifier struct {
+	mailer biz.Mailer
+}
+
+func (n *emailLoginNotifier) NotifyNewDevice(ctx context.Context, user *biz.User, entry *biz.LoginHistory) error {
+	return n.mailer.Send(ctx, &biz.MailMessage{
+		To:      user.Email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nYour account was just signed in to from a new device:\n\n"+
+				"  Device:     %s\n  IP address: %s\n  Time:       %s\n\n"+
+				"If this was you, you can ignore this email. If not, change your password now and "+
+				"sign out the session from your list of active sessions.\n",
+			user.GetDisplayName(), entry.DeviceName, entry.IPAddress, entry.CreatedAt.UTC().Format(time.RFC1123),
+		),
+	})
+}
+
+// webhookLoginNotifier posts the event as JSON, e.g. to a notification service or SIEM
+type webhookLoginNotifier struct {
+	url    string
+	secret []byte
+	client *http.Client
+	log    *log.Helper
+}
+
+type loginWebhookEvent struct {
+	Event      string    `json:"event"`
+	UserID     string    `json:"user_id"`
+	Email      string    `json:"email"`
+	Method     string    `json:"method"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Device     string    `json:"device"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (n *webhookLoginNotifier) NotifyNewDevice(ctx context.Context, user *biz.User, entry *biz.LoginHistory) error {
+	body, err := json.Marshal(loginWebhookEvent{
+		Event:      EventLoginNewDevice,
+		UserID:     user.ID.String(),
+		Email:      user.Email,
+		Method:     entry.Method,
+		IPAddress:  entry.IPAddress,
+		UserAgent:  entry.UserAgent,
+		Device:     entry.DeviceName,
+		OccurredAt: entry.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		mac := hmac.New(sha256.New, n.secret)
+		mac.Write(body)
+		req.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("login notification webhook returned %s", resp.Status)
+	}
+	n.log.WithContext(ctx).Infof("New device notification for %s posted to webhook", user.Email)
+	return nil
+}
@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	MailDriverSMTP   = "smtp"
+	MailDriverOutbox = "outbox"
+
+	defaultMailFrom  = "no-reply@localhost"
+	defaultOutboxDir = "./tmp/outbox"
+)
+
+// NewMailer creates the mailer selected by mail.driver (outbox by default)
+func NewMailer(c *conf.Mail, logger log.Logger) (biz.Mailer, error) {
+	driver := MailDriverOutbox
+	from := defaultMailFrom
+	if c != nil {
+		if c.Driver != "" {
+			driver = c.Driver
+		}
+		if c.From != "" {
+			from = c.From
+		}
+	}
+
+	switch driver {
+	case MailDriverSMTP:
+		if c.Smtp == nil || c.Smtp.Host == "" {
+			return nil, fmt.Errorf("mail: smtp driver requires mail.smtp.host")
+		}
+		port := int(c.Smtp.Port)
+		if port == 0 {
+			port = 587
+		}
+		return &smtpMailer{
+			addr:     net.JoinHostPort(c.Smtp.Host, strconv.Itoa(port)),
+			host:     c.Smtp.Host,
+			username: c.Smtp.Username,
+			password: c.Smtp.Password,
+			from:     from,
+			log:      log.NewHelper(logger),
+		}, nil
+	case MailDriverOutbox:
+		dir := defaultOutboxDir
+		if c != nil && c.OutboxDir != "" {
+			dir = c.OutboxDir
+		}
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("mail: create outbox dir: %w", err)
+		}
+		return &outboxMailer{
+			dir:  dir,
+			from: from,
+			log:  log.NewHelper(logger),
+		}, nil
+	default:
+		return nil, fmt.Errorf("mail: unknown driver %q", driver)
+	}
+}
+
+// smtpMailer sends mail through an SMTP relay (STARTTLS is used when the server offers it)
+type smtpMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	log      *log.Helper
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg *biz.MailMessage) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(m.addr, auth, envelopeAddress(m.from), []string{msg.To}, buildMail(m.from, msg)); err != nil {
+		m.log.WithContext(ctx).Errorf("Failed to send mail to %s: %v", msg.To, err)
+		return err
+	}
+	return nil
+}
+
+// outboxMailer writes every mail as an .eml file instead of sending it
+type outboxMailer struct {
+	dir  string
+	from string
+	log  *log.Helper
+}
+
+func (m *outboxMailer) Send(ctx context.Context, msg *biz.MailMessage) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.Must(uuid.NewV7()).String())
+	path := filepath.Join(m.dir, name)
+
+	if err := os.WriteFile(path, buildMail(m.from, msg), 0o640); err != nil {
+		m.log.WithContext(ctx).Errorf("Failed to write mail to outbox: %v", err)
+		return err
+	}
+
+	m.log.WithContext(ctx).Infof("Mail to %s written to %s", msg.To, path)
+	return nil
+}
+
+// buildMail renders a plain-text RFC 5322 message
+func buildMail(from string, msg *biz.MailMessage) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + headerValue(from) + "\r\n")
+	b.WriteString("To: " + headerValue(msg.To) + "\r\n")
+	b.WriteString("Subject: " + headerValue(msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// headerValue strips line breaks so values cannot inject extra headers
+func headerValue(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
+
+// envelopeAddress extracts the bare address from "Name <addr>"
+func envelopeAddress(from string) string {
+	if addr, err := mail.ParseAddress(from); err == nil {
+		return addr.Address
+	}
+	return from
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type mfaCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewMFACommandRepo(data *Data, logger log.Logger) biz.MFACommandRepo {
+	return &mfaCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *mfaCommandRepo) SaveSecret(ctx context.Context, secret *biz.MFASecret) (*biz.MFASecret, error) {
+	db := r.data.GetWriteDB()
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Drop the previous pending enrollment; an enabled one is never replaced
+		if err := tx.Unscoped().
+			Where("user_id = ? AND enabled = ?", secret.UserID, false).
+			Delete(&biz.MFASecret{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(secret).Error
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save MFA secret: %v", err)
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (r *mfaCommandRepo) EnableMFA(ctx context.Context, userID uuid.UUID, step int64, codes []*biz.MFARecoveryCode) error {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&biz.MFASecret{}).
+			Where("user_id = ? AND enabled = ?", userID, false).
+			Updates(map[string]interface{}{
+				"enabled":        true,
+				"confirmed_at":   &now,
+				"last_used_step": step,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return biz.ErrMFAAlreadyEnabled
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&biz.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		if err != biz.ErrMFAAlreadyEnabled {
+			r.log.WithContext(ctx).Errorf("Failed to enable MFA: %v", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *mfaCommandRepo) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	db := r.data.GetWriteDB()
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&biz.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("user_id = ?", userID).Delete(&biz.MFASecret{}).Error
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to disable MFA: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *mfaCommandRepo) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	db := r.data.GetWriteDB()
+
+	// Conditional update so two requests cannot both use the same code
+	result := db.WithContext(ctx).Model(&biz.MFASecret{}).
+		Where("user_id = ? AND enabled = ? AND last_used_step < ?", userID, true, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to record MFA step: %v", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *mfaCommandRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	result := db.WithContext(ctx).Model(&biz.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", &now)
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to use recovery code: %v", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
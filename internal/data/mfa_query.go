@@ -0,0 +1,41 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type mfaQueryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewMFAQueryRepo(data *Data, logger log.Logger) biz.MFAQueryRepo {
+	return &mfaQueryRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *mfaQueryRepo) FindSecretByUserID(ctx context.Context, userID uuid.UUID) (*biz.MFASecret, error) {
+	// Read from the write DB: enrollment and confirmation happen seconds apart
+	db := r.data.GetWriteDB()
+	var secret biz.MFASecret
+
+	if err := db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&secret).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.WithContext(ctx).Errorf("Failed to find MFA secret: %v", err)
+		return nil, err
+	}
+
+	return &secret, nil
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm/clause"
+)
+
+type oidcCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewOIDCCommandRepo(data *Data, logger log.Logger) biz.OIDCCommandRepo {
+	return &oidcCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *oidcCommandRepo) SaveLoginState(ctx context.Context, state *biz.OIDCLoginState) (*biz.OIDCLoginState, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(state).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save OIDC login state: %v", err)
+		return nil, err
+	}
+	return state, nil
+}
+
+func (r *oidcCommandRepo) UseLoginState(ctx context.Context, stateHash string) (*biz.OIDCLoginState, error) {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	// Single statement so a callback can only be completed once
+	var states []*biz.OIDCLoginState
+	result := db.WithContext(ctx).Model(&states).
+		Clauses(clause.Returning{}).
+		Where("state_hash = ? AND used_at IS NULL AND expires_at > ?", stateHash, now).
+		Update("used_at", &now)
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to use OIDC login state: %v", result.Error)
+		return nil, result.Error
+	}
+
+	if len(states) == 0 {
+		return nil, nil
+	}
+	return states[0], nil
+}
+
+func (r *oidcCommandRepo) SaveIdentity(ctx context.Context, identity *biz.UserIdentity) (*biz.UserIdentity, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(identity).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save user identity: %v", err)
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (r *oidcCommandRepo) UpdateIdentityLastLogin(ctx context.Context, id uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+	if err := db.WithContext(ctx).Model(&biz.UserIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", &now).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to update identity last login: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type oidcQueryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewOIDCQueryRepo(data *Data, logger log.Logger) biz.OIDCQueryRepo {
+	return &oidcQueryRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *oidcQueryRepo) FindIdentity(ctx context.Context, provider, subject string) (*biz.UserIdentity, error) {
+	db := r.data.GetReadDB()
+	var identity biz.UserIdentity
+
+	if err := db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.WithContext(ctx).Errorf("Failed to find user identity: %v", err)
+		return nil, err
+	}
+
+	return &identity, nil
+}
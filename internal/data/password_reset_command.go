@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type passwordResetCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewPasswordResetCommandRepo(data *Data, logger log.Logger) biz.PasswordResetCommandRepo {
+	return &passwordResetCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *passwordResetCommandRepo) SaveResetToken(ctx context.Context, token *biz.PasswordResetToken) (*biz.PasswordResetToken, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Create(token).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save reset token: %v", err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *passwordResetCommandRepo) UseResetToken(ctx context.Context, tokenHash string) (*biz.PasswordResetToken, error) {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	// Single statement so a token can only be used once, even by concurrent requests
+	var tokens []*biz.PasswordResetToken
+	result := db.WithContext(ctx).Model(&tokens).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		Update("used_at", &now)
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to use reset token: %v", result.Error)
+		return nil, result.Error
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return tokens[0], nil
+}
+
+func (r *passwordResetCommandRepo) FindValidResetToken(ctx context.Context, tokenHash string) (*biz.PasswordResetToken, error) {
+	// Read from the primary: the token may have just been issued
+	db := r.data.GetWriteDB()
+
+	var token biz.PasswordResetToken
+	if err := db.WithContext(ctx).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.WithContext(ctx).Errorf("Failed to find reset token: %v", err)
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetCommandRepo) InvalidateUserResetTokens(ctx context.Context, userID uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	now := time.Now()
+
+	if err := db.WithContext(ctx).Model(&biz.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", &now).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to invalidate reset tokens: %v", err)
+		return err
+	}
+	return nil
+}
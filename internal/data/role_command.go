@@ -0,0 +1,126 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type roleCommandRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewRoleCommandRepo(data *Data, logger log.Logger) biz.RoleCommandRepo {
+	return &roleCommandRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *roleCommandRepo) SaveRole(ctx context.Context, role *biz.Role) (*biz.Role, error) {
+	db := r.data.GetWriteDB()
+	// Permissions are set separately, never upserted through the association
+	if err := db.WithContext(ctx).Omit("Permissions").Create(role).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to save role: %v", err)
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleCommandRepo) UpdateRole(ctx context.Context, role *biz.Role) (*biz.Role, error) {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Omit("Permissions").Save(role).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to update role: %v", err)
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleCommandRepo) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", roleID).Error; err != nil {
+			return err
+		}
+		for _, permissionID := range permissionIDs {
+			if err := tx.Exec(
+				"INSERT INTO role_permissions (role_id, permission_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+				roleID, permissionID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to set role permissions: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *roleCommandRepo) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	// Hard delete so the name can be reused; role_permissions and user_roles cascade
+	if err := db.WithContext(ctx).Unscoped().Delete(&biz.Role{}, "id = ?", id).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to delete role: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *roleCommandRepo) AssignUserRole(ctx context.Context, userID, roleID uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Exec(
+		"INSERT INTO user_roles (user_id, role_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		userID, roleID,
+	).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to assign role: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *roleCommandRepo) RemoveUserRole(ctx context.Context, userID, roleID uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Exec(
+		"DELETE FROM user_roles WHERE user_id = ? AND role_id = ?",
+		userID, roleID,
+	).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to remove role: %v", err)
+		return err
+	}
+	return nil
+}
+
+// syncPrimaryRole keeps user_roles in step with users.role: the previous primary
+// role (if any) is replaced by the new one. Runs inside the user's transaction.
+// Role names are looked up among the shared roles and those of the user's tenant.
+func syncPrimaryRole(tx *gorm.DB, userID uuid.UUID, oldRole, newRole string) error {
+	if oldRole == newRole {
+		return nil
+	}
+	if oldRole != "" {
+		if err := tx.Exec(
+			"DELETE FROM user_roles WHERE user_id = ? AND role_id IN (SELECT id FROM roles WHERE name = ? AND "+userTenantRoles+")",
+			userID, oldRole, userID,
+		).Error; err != nil {
+			return err
+		}
+	}
+	if newRole == "" {
+		return nil
+	}
+	return tx.Exec(
+		"INSERT INTO user_roles (user_id, role_id) SELECT ?, id FROM roles WHERE name = ? AND "+userTenantRoles+" ON CONFLICT DO NOTHING",
+		userID, newRole, userID,
+	).Error
+}
+
+// userTenantRoles restricts roles to the shared ones and those of a user's tenant (the user id is its argument)
+const userTenantRoles = "(tenant_id IS NULL OR tenant_id = (SELECT tenant_id FROM users WHERE id = ?))"
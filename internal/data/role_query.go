@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type roleQueryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewRoleQueryRepo(data *Data, logger log.Logger) biz.RoleQueryRepo {
+	return &roleQueryRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *roleQueryRepo) ListPermissions(ctx context.Context) ([]*biz.Permission, error) {
+	db := r.data.GetReadDB()
+	var permissions []*biz.Permission
+	if err := db.WithContext(ctx).Order("name").Find(&permissions).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list permissions: %v", err)
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *roleQueryRepo) ListRoles(ctx context.Context) ([]*biz.Role, error) {
+	db := r.data.GetReadDB()
+	var roles []*biz.Role
+	if err := db.WithContext(ctx).
+		Preload("Permissions", orderByName).
+		Order("name").
+		Find(&roles).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list roles: %v", err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleQueryRepo) FindRoleByID(ctx context.Context, id uuid.UUID) (*biz.Role, error) {
+	return r.findRole(ctx, "id = ?", id)
+}
+
+func (r *roleQueryRepo) FindRoleByName(ctx context.Context, name string) (*biz.Role, error) {
+	return r.findRole(ctx, "name = ?", name)
+}
+
+func (r *roleQueryRepo) findRole(ctx context.Context, query string, arg interface{}) (*biz.Role, error) {
+	db := r.data.GetReadDB()
+	var role biz.Role
+	if err := db.WithContext(ctx).
+		Preload("Permissions", orderByName).
+		Where(query, arg).
+		First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.WithContext(ctx).Errorf("Failed to find role: %v", err)
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleQueryRepo) CountUsersWithPrimaryRole(ctx context.Context, name string) (int64, error) {
+	db := r.data.GetReadDB()
+	var count int64
+	if err := db.WithContext(ctx).Model(&biz.User{}).Where("role = ?", name).Count(&count).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to count users with role: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *roleQueryRepo) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]*biz.Role, error) {
+	db := r.data.GetReadDB()
+	var roles []*biz.Role
+	if err := db.WithContext(ctx).
+		Preload("Permissions", orderByName).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&roles).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list user roles: %v", err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleQueryRepo) ListUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	db := r.data.GetReadDB()
+	var permissions []string
+	if err := db.WithContext(ctx).Raw(`
+		SELECT DISTINCT p.name
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL
+		JOIN role_permissions rp ON rp.role_id = r.id
+		JOIN permissions p ON p.id = rp.permission_id AND p.deleted_at IS NULL
+		WHERE ur.user_id = ?
+		ORDER BY p.name`, userID,
+	).Scan(&permissions).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list user permissions: %v", err)
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func orderByName(db *gorm.DB) *gorm.DB {
+	return db.Order("name")
+}
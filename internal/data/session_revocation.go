@@ -0,0 +1,116 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultRevocationCacheTTL  = 30 * time.Second
+	defaultRevocationCacheSize = 10000
+
+	revokedSessionKeyPrefix = "auth:revoked_session:"
+)
+
+// NewSessionRevocationRepo uses redis when configured (revocations are visible to
+// every instance immediately), otherwise an in-memory LRU backed by auth_tokens
+func NewSessionRevocationRepo(data *Data, c *conf.Auth, logger log.Logger) biz.SessionRevocationRepo {
+	if rdb := data.GetRedis(); rdb != nil {
+		return &redisSessionRevocationRepo{
+			rdb: rdb,
+			log: log.NewHelper(logger),
+		}
+	}
+
+	ttl := defaultRevocationCacheTTL
+	size := defaultRevocationCacheSize
+	if c != nil {
+		if c.RevocationCacheTtl != nil && c.RevocationCacheTtl.AsDuration() > 0 {
+			ttl = c.RevocationCacheTtl.AsDuration()
+		}
+		if c.RevocationCacheSize > 0 {
+			size = int(c.RevocationCacheSize)
+		}
+	}
+
+	return &dbSessionRevocationRepo{
+		data:    data,
+		active:  lru.NewLRU[uuid.UUID, struct{}](size, nil, ttl),
+		revoked: lru.NewLRU[uuid.UUID, struct{}](size, nil, 0),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// dbSessionRevocationRepo caches lookups against auth_tokens.
+// Revocations made by this instance apply immediately; revocations made by other
+// instances apply once the cached "active" entry expires.
+type dbSessionRevocationRepo struct {
+	data    *Data
+	active  *lru.LRU[uuid.UUID, struct{}]
+	revoked *lru.LRU[uuid.UUID, struct{}]
+	log     *log.Helper
+}
+
+func (r *dbSessionRevocationRepo) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	if _, ok := r.revoked.Get(sessionID); ok {
+		return true, nil
+	}
+	if _, ok := r.active.Get(sessionID); ok {
+		return false, nil
+	}
+
+	// A session is active while its family still has a non-revoked token
+	db := r.data.GetReadDB()
+	var count int64
+	if err := db.WithContext(ctx).Model(&biz.AuthToken{}).
+		Where("family_id = ? AND revoked = ?", sessionID, false).
+		Count(&count).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to check session revocation: %v", err)
+		return false, err
+	}
+
+	if count == 0 {
+		r.revoked.Add(sessionID, struct{}{})
+		return true, nil
+	}
+	r.active.Add(sessionID, struct{}{})
+	return false, nil
+}
+
+func (r *dbSessionRevocationRepo) MarkSessionRevoked(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	r.active.Remove(sessionID)
+	r.revoked.Add(sessionID, struct{}{})
+	return nil
+}
+
+// redisSessionRevocationRepo keeps a revocation marker per session for as long as
+// its access tokens could still be valid
+type redisSessionRevocationRepo struct {
+	rdb *redis.Client
+	log *log.Helper
+}
+
+func (r *redisSessionRevocationRepo) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	n, err := r.rdb.Exists(ctx, revokedSessionKeyPrefix+sessionID.String()).Result()
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to check session revocation: %v", err)
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *redisSessionRevocationRepo) MarkSessionRevoked(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	if err := r.rdb.Set(ctx, revokedSessionKeyPrefix+sessionID.String(), 1, ttl).Err(); err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to mark session revoked: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type tenantQueryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewTenantQueryRepo(data *Data, logger log.Logger) biz.TenantQueryRepo {
+	return &tenantQueryRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *tenantQueryRepo) FindTenantByID(ctx context.Context, id uuid.UUID) (*biz.Tenant, error) {
+	db := r.data.GetReadDB()
+	var t biz.Tenant
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&t).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.WithContext(ctx).Errorf("Failed to find tenant: %v", err)
+		return nil, err
+	}
+	return &t, nil
+}
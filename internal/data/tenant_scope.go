@@ -0,0 +1,137 @@
+package data
+
+import (
+	"reflect"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+
+	"github.com/gofrs/uuid/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const tenantColumn = "tenant_id"
+
+// registerTenantScope scopes every statement on a model with a TenantID (every BaseEntity)
+// to the tenant of the statement's context, so repositories cannot forget the filter:
+//   - create: TenantID is set to the tenant when empty
+//   - query: only rows of the tenant, plus shared rows (tenant_id IS NULL) of biz.TenantShared models
+//   - update/delete: only rows of the tenant, shared rows are never changed
+//
+// Contexts from tenant.WithAllTenants are not scoped. Raw SQL is not scoped either,
+// it must filter on tenant_id itself.
+func registerTenantScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:create", tenantCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:query", tenantQuery); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant:row", tenantQuery); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:update", tenantUpdate); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenant:delete", tenantDelete)
+}
+
+// tenantScope returns the tenant and TenantID field of the statement, ok is false if it is not scoped
+func tenantScope(db *gorm.DB) (uuid.UUID, *schema.Field, bool) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.SQL.Len() > 0 {
+		return uuid.Nil, nil, false
+	}
+	field := stmt.Schema.LookUpField("TenantID")
+	if field == nil || field.DBName != tenantColumn {
+		return uuid.Nil, nil, false
+	}
+	id, ok := tenant.FromContext(stmt.Context)
+	return id, field, ok
+}
+
+func tenantCreate(db *gorm.DB) {
+	id, field, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	stmt := db.Statement
+
+	setTenant := func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		if rv.Kind() != reflect.Struct {
+			return
+		}
+		if _, zero := field.ValueOf(stmt.Context, rv); zero {
+			tenantID := id
+			if err := field.Set(stmt.Context, rv, &tenantID); err != nil {
+				_ = db.AddError(err)
+			}
+		}
+	}
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			setTenant(stmt.ReflectValue.Index(i))
+		}
+	case reflect.Struct:
+		setTenant(stmt.ReflectValue)
+	}
+
+	// Save falls back to an upsert on the primary key: never overwrite a row of another tenant
+	if c, ok := stmt.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && !onConflict.DoNothing {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs, tenantEq(id))
+			stmt.AddClause(onConflict)
+		}
+	}
+}
+
+func tenantQuery(db *gorm.DB) {
+	id, _, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	if isTenantShared(db.Statement.Schema) {
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Or(tenantEq(id), clause.Eq{Column: tenantColumnRef(), Value: nil}),
+		}})
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{tenantEq(id)}})
+}
+
+func tenantUpdate(db *gorm.DB) {
+	id, _, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	// A row never moves to another tenant, even when saved from a struct without TenantID
+	db.Statement.Omits = append(db.Statement.Omits, tenantColumn)
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{tenantEq(id)}})
+}
+
+func tenantDelete(db *gorm.DB) {
+	id, _, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{tenantEq(id)}})
+}
+
+func tenantColumnRef() clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: tenantColumn}
+}
+
+func tenantEq(id uuid.UUID) clause.Expression {
+	return clause.Eq{Column: tenantColumnRef(), Value: id}
+}
+
+// isTenantShared reports whether rows without a tenant are visible to every tenant (see biz.TenantShared)
+func isTenantShared(s *schema.Schema) bool {
+	shared, ok := reflect.New(s.ModelType).Interface().(biz.TenantShared)
+	return ok && shared.TenantShared()
+}
@@ -0,0 +1,316 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	tenantA = uuid.Must(uuid.FromString("0193a000-0000-7000-8000-00000000000a"))
+	tenantB = uuid.Must(uuid.FromString("0193a000-0000-7000-8000-00000000000b"))
+	rowID   = uuid.Must(uuid.FromString("0193a000-0000-7000-8000-000000000001"))
+)
+
+// sqlRecorder is a GORM logger keeping the statements of a dry run, with their values
+type sqlRecorder struct {
+	logger.Interface
+	mu  sync.Mutex
+	sql []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface { return r }
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sql = append(r.sql, sql)
+}
+
+// last returns the statement of the previous call and forgets every statement
+func (r *sqlRecorder) last(t *testing.T) string {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.sql) == 0 {
+		t.Fatal("no statement was built")
+	}
+	sql := r.sql[len(r.sql)-1]
+	r.sql = nil
+	return sql
+}
+
+// newTenantTestDB returns a scoped database that builds statements without a server
+func newTenantTestDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+	recorder := &sqlRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 dbname=tenant_test"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+		Logger:                 recorder,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registerTenantScope(db); err != nil {
+		t.Fatal(err)
+	}
+	return db, recorder
+}
+
+func tenantFilter(table string, id uuid.UUID) string {
+	return `"` + table + `"."tenant_id" = '` + id.String() + `'`
+}
+
+func sharedFilter(table string) string {
+	return `"` + table + `"."tenant_id" IS NULL`
+}
+
+func assertSQL(t *testing.T, sql string, contains []string, excludes []string) {
+	t.Helper()
+	for _, s := range contains {
+		if !strings.Contains(sql, s) {
+			t.Errorf("statement misses %s:\n%s", s, sql)
+		}
+	}
+	for _, s := range excludes {
+		if strings.Contains(sql, s) {
+			t.Errorf("statement must not contain %s:\n%s", s, sql)
+		}
+	}
+}
+
+func TestTenantScopeReadsOnlyTheTenantsRows(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	tests := []struct {
+		name   string
+		query  func(*gorm.DB)
+		table  string
+		shared bool // Shared rows (tenant_id IS NULL) are visible too
+	}{
+		{"users", func(tx *gorm.DB) { tx.Where("email = ?", "a@example.com").First(&biz.User{}) }, "users", false},
+		{"auth tokens", func(tx *gorm.DB) { tx.Where("token_hash = ?", "h").Find(&[]biz.AuthToken{}) }, "auth_tokens", false},
+		{"api keys", func(tx *gorm.DB) { tx.Find(&[]biz.APIKey{}) }, "api_keys", false},
+		{"provinces", func(tx *gorm.DB) { tx.Find(&[]biz.Province{}) }, "provinces", false},
+		{"wards count", func(tx *gorm.DB) { var n int64; tx.Model(&biz.Ward{}).Count(&n) }, "wards", false},
+		{"countries", func(tx *gorm.DB) { tx.Where("code = ?", "VN").First(&biz.Country{}) }, "countries", true},
+		{"roles", func(tx *gorm.DB) { tx.Find(&[]biz.Role{}) }, "roles", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query(db.WithContext(ctx))
+			sql := recorder.last(t)
+
+			contains := []string{tenantFilter(tt.table, tenantA)}
+			excludes := []string{tenantFilter(tt.table, tenantB)}
+			if tt.shared {
+				contains = append(contains, "("+tenantFilter(tt.table, tenantA)+" OR "+sharedFilter(tt.table)+")")
+			} else {
+				excludes = append(excludes, sharedFilter(tt.table))
+			}
+			assertSQL(t, sql, contains, excludes)
+		})
+	}
+}
+
+func TestTenantScopeJoinedRows(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	db.WithContext(ctx).Joins("Province").Find(&[]biz.Ward{})
+	assertSQL(t, recorder.last(t), []string{tenantFilter("wards", tenantA)}, nil)
+}
+
+func TestTenantScopeCannotChangeOtherTenantsRows(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	tests := []struct {
+		name    string
+		command func(*gorm.DB)
+		table   string
+		verb    string
+	}{
+		{"update columns", func(tx *gorm.DB) {
+			tx.Model(&biz.User{}).Where("id = ?", rowID).Updates(map[string]interface{}{"full_name": "x"})
+		}, "users", "UPDATE"},
+		{"save struct", func(tx *gorm.DB) {
+			u := &biz.Province{Code: "HN"}
+			u.ID = rowID
+			tx.Save(u)
+		}, "provinces", "UPDATE"},
+		{"soft delete", func(tx *gorm.DB) { tx.Delete(&biz.Ward{}, "id = ?", rowID) }, "wards", "UPDATE"},
+		{"hard delete", func(tx *gorm.DB) { tx.Unscoped().Delete(&biz.AuthToken{}, "id = ?", rowID) }, "auth_tokens", "DELETE"},
+		// Shared countries and roles are read by every tenant but changed by none
+		{"update shared country", func(tx *gorm.DB) {
+			tx.Model(&biz.Country{}).Where("id = ?", rowID).Update("name", "x")
+		}, "countries", "UPDATE"},
+		{"delete shared role", func(tx *gorm.DB) { tx.Delete(&biz.Role{}, "id = ?", rowID) }, "roles", "UPDATE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.command(db.WithContext(ctx))
+			sql := recorder.last(t)
+			if !strings.HasPrefix(sql, tt.verb) {
+				t.Fatalf("statement is not an %s:\n%s", tt.verb, sql)
+			}
+			assertSQL(t, sql, []string{tenantFilter(tt.table, tenantA)}, []string{sharedFilter(tt.table)})
+		})
+	}
+}
+
+func TestTenantScopeUpdateNeverMovesRows(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	// A struct claiming another tenant (e.g. decoded from a request) keeps its row in tenant A
+	u := &biz.User{Email: "a@example.com"}
+	u.ID = rowID
+	u.TenantID = &tenantB
+	db.WithContext(ctx).Save(u)
+	sql := recorder.last(t)
+	set, where, _ := strings.Cut(sql, "WHERE")
+	if strings.Contains(set, `"tenant_id"`) {
+		t.Errorf("UPDATE sets tenant_id:\n%s", sql)
+	}
+	assertSQL(t, where, []string{tenantFilter("users", tenantA)}, []string{tenantB.String()})
+}
+
+func TestTenantScopeCreate(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	province := &biz.Province{Code: "HN"}
+	db.WithContext(ctx).Create(province)
+	recorder.last(t)
+	if province.TenantID == nil || *province.TenantID != tenantA {
+		t.Errorf("created province tenant = %v, want %s", province.TenantID, tenantA)
+	}
+
+	wards := []*biz.Ward{{Code: "00001"}, {Code: "00002"}}
+	db.WithContext(ctx).Create(&wards)
+	recorder.last(t)
+	for _, w := range wards {
+		if w.TenantID == nil || *w.TenantID != tenantA {
+			t.Errorf("created ward tenant = %v, want %s", w.TenantID, tenantA)
+		}
+	}
+
+	// Requests without a tenant belong to the default tenant
+	user := &biz.User{Email: "a@example.com"}
+	db.WithContext(context.Background()).Create(user)
+	recorder.last(t)
+	if user.TenantID == nil || *user.TenantID != tenant.DefaultID {
+		t.Errorf("created user tenant = %v, want the default tenant", user.TenantID)
+	}
+}
+
+func TestTenantScopeUpsertCannotOverwriteOtherTenantsRow(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	// Save falls back to this upsert when its UPDATE matched no row of the tenant,
+	// the id may then belong to another tenant
+	u := &biz.User{Email: "a@example.com"}
+	u.ID = rowID
+	db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(u)
+	sql := recorder.last(t)
+	_, onConflict, found := strings.Cut(sql, "ON CONFLICT")
+	if !found {
+		t.Fatalf("no ON CONFLICT clause:\n%s", sql)
+	}
+	assertSQL(t, onConflict, []string{"DO UPDATE SET", "WHERE " + tenantFilter("users", tenantA)}, nil)
+
+	// DO NOTHING overwrites nothing, it is left as is
+	db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&biz.User{Email: "b@example.com"})
+	_, onConflict, _ = strings.Cut(recorder.last(t), "ON CONFLICT")
+	if !strings.HasPrefix(onConflict, " DO NOTHING") || strings.Contains(onConflict, "WHERE") {
+		t.Errorf("DO NOTHING was changed: ON CONFLICT%s", onConflict)
+	}
+}
+
+func TestTenantScopeIsLiftedForAllTenants(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.WithAllTenants(context.Background())
+
+	db.WithContext(ctx).Where("refresh_token_hash = ?", "h").First(&biz.AuthToken{})
+	assertSQL(t, recorder.last(t), nil, []string{"tenant_id"})
+
+	db.WithContext(ctx).Model(&biz.AuthToken{}).Where("id = ?", rowID).Update("revoked", true)
+	assertSQL(t, recorder.last(t), nil, []string{"tenant_id"})
+
+	// Rows created without a tenant scope are shared
+	country := &biz.Country{Code: "VN"}
+	db.WithContext(ctx).Create(country)
+	recorder.last(t)
+	if country.TenantID != nil {
+		t.Errorf("country created for all tenants belongs to %s", country.TenantID)
+	}
+}
+
+func TestTenantScopeSkipsRawSQL(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	ctx := tenant.NewContext(context.Background(), tenantA)
+
+	// Raw SQL filters on tenant_id itself (see syncPrimaryRole), the scope must not add a second filter
+	tx := db.WithContext(ctx).Raw("SELECT id FROM users WHERE email = ?", "a@example.com").Find(&[]biz.User{})
+	assertSQL(t, recorder.last(t), nil, []string{"tenant_id"})
+	if where := fmt.Sprint(tx.Statement.Clauses["WHERE"].Expression); strings.Contains(where, tenantColumn) {
+		t.Errorf("a tenant condition was added to raw SQL: %s", where)
+	}
+}
+
+func TestTenantScopeThroughRepositories(t *testing.T) {
+	db, recorder := newTenantTestDB(t)
+	data := &Data{readDB: db, writeDB: db}
+	logger := log.NewStdLogger(io.Discard)
+	countries := NewCountryCommandRepo(data, logger)
+	countryQuery := NewCountryQueryRepo(data, logger)
+	wards := NewWardCommandRepo(data, logger)
+	users := NewUserQueryRepo(data, logger)
+	userCommands := NewUserCommandRepo(data, logger)
+	ctx := tenant.NewContext(context.Background(), tenantB)
+
+	tests := []struct {
+		name  string
+		call  func()
+		table string
+	}{
+		{"find user", func() { _, _ = users.FindByID(ctx, rowID) }, "users"},
+		{"count failed login", func() { _, _ = userCommands.IncrementFailedLogins(ctx, rowID) }, "users"},
+		{"lock user", func() { _ = userCommands.LockUntil(ctx, rowID, time.Now()) }, "users"},
+		{"unlock user", func() { _ = userCommands.ResetFailedLogins(ctx, rowID) }, "users"},
+		{"find country", func() { _, _ = countryQuery.FindByCode(ctx, "vn") }, "countries"},
+		{"update country", func() {
+			c := &biz.Country{Code: "VN"}
+			c.ID = rowID
+			_, _ = countries.Update(ctx, c)
+		}, "countries"},
+		{"delete country", func() { _ = countries.Delete(ctx, rowID) }, "countries"},
+		{"delete ward", func() { _ = wards.Delete(ctx, rowID) }, "wards"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.call()
+			assertSQL(t, recorder.last(t), []string{tenantFilter(tt.table, tenantB)}, []string{tenantA.String()})
+		})
+	}
+}
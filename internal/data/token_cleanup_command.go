@@ -0,0 +1,98 @@
+package data
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// tokenCleanupLockKey is the postgres advisory lock key held by the instance running the cleanup
+const tokenCleanupLockKey int64 = 0x617574685f746f6b // "auth_tok"
+
+// Columns copied to auth_tokens_archive (see migration 016)
+const authTokenArchiveColumns = `id, tenant_id, created_at, updated_at, deleted_at, created_by, updated_by, version, status,
+	user_id, token_hash, refresh_token_hash, expires_at, refresh_expires_at, ip_address, user_agent,
+	revoked, revoked_at, last_used_at, family_id, replaced_by_id`
+
+const expiredTokensCTE = `WITH expired AS (
+	SELECT id FROM auth_tokens
+	WHERE refresh_expires_at < ? OR (revoked AND revoked_at < ?)
+	ORDER BY id
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)`
+
+type tokenCleanupRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewTokenCleanupRepo(data *Data, logger log.Logger) biz.TokenCleanupRepo {
+	return &tokenCleanupRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// RunExclusive holds a session-level advisory lock on a dedicated connection while fn runs.
+// If the instance dies the connection closes and postgres releases the lock.
+func (r *tokenCleanupRepo) RunExclusive(ctx context.Context, fn func(context.Context) error) (bool, error) {
+	sqlDB, err := r.data.GetWriteDB().DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to get connection for cleanup lock: %v", err)
+		return false, err
+	}
+	defer conn.Close()
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", tokenCleanupLockKey).Scan(&acquired); err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to acquire cleanup lock: %v", err)
+		return false, err
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	defer func() {
+		// Unlock even if ctx was cancelled, otherwise the pooled connection keeps the lock
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(unlockCtx, "SELECT pg_advisory_unlock($1)", tokenCleanupLockKey); err != nil {
+			r.log.WithContext(ctx).Errorf("Failed to release cleanup lock, dropping the connection: %v", err)
+			// Closing the session is the only other way to release the lock
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+func (r *tokenCleanupRepo) PurgeTokens(ctx context.Context, cutoff time.Time, limit int, archive bool) (int64, error) {
+	db := r.data.GetWriteDB()
+
+	query := expiredTokensCTE + `
+DELETE FROM auth_tokens t USING expired e WHERE t.id = e.id`
+	if archive {
+		query = expiredTokensCTE + `, deleted AS (
+	DELETE FROM auth_tokens t USING expired e WHERE t.id = e.id
+	RETURNING t.*
+)
+INSERT INTO auth_tokens_archive (` + authTokenArchiveColumns + `, archived_at)
+SELECT ` + authTokenArchiveColumns + `, CURRENT_TIMESTAMP FROM deleted`
+	}
+
+	result := db.WithContext(ctx).Exec(query, cutoff, cutoff, limit)
+	if result.Error != nil {
+		r.log.WithContext(ctx).Errorf("Failed to purge tokens: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/gofrs/uuid/v5"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userCommandRepo struct {
@@ -24,7 +26,14 @@ func NewUserCommandRepo(data *Data, logger log.Logger) biz.UserCommandRepo {
 
 func (r *userCommandRepo) Save(ctx context.Context, u *biz.User) (*biz.User, error) {
 	db := r.data.GetWriteDB()
-	if err := db.WithContext(ctx).Create(u).Error; err != nil {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(u).Error; err != nil {
+			return err
+		}
+		// The primary role is also the user's first role assignment
+		return syncPrimaryRole(tx, u.ID, "", u.Role)
+	})
+	if err != nil {
 		r.log.WithContext(ctx).Errorf("Failed to save user: %v", err)
 		return nil, err
 	}
@@ -33,7 +42,20 @@ func (r *userCommandRepo) Save(ctx context.Context, u *biz.User) (*biz.User, err
 
 func (r *userCommandRepo) Update(ctx context.Context, u *biz.User) (*biz.User, error) {
 	db := r.data.GetWriteDB()
-	if err := db.WithContext(ctx).Save(u).Error; err != nil {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current biz.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "role").
+			Where("id = ?", u.ID).
+			First(&current).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(u).Error; err != nil {
+			return err
+		}
+		return syncPrimaryRole(tx, u.ID, current.Role, u.Role)
+	})
+	if err != nil {
 		r.log.WithContext(ctx).Errorf("Failed to update user: %v", err)
 		return nil, err
 	}
@@ -50,11 +72,47 @@ func (r *userCommandRepo) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *userCommandRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	now := time.Now()
+	db := r.data.GetWriteDB()
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Keep the replaced hash so it cannot be reused
+		var user biz.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "password_hash").
+			Where("id = ?", id).
+			First(&user).Error; err != nil {
+			return err
+		}
+		history := &biz.PasswordHistory{UserID: id, PasswordHash: user.PasswordHash}
+		history.SetAuditFields(ctx, true)
+		if history.CreatedBy == nil {
+			history.CreatedBy = &id
+		}
+		if err := tx.Create(history).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&biz.User{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"password_hash":       passwordHash,
+				"password_changed_at": &now,
+			}).Error
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to update password: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *userCommandRepo) RehashPassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
 	db := r.data.GetWriteDB()
 	if err := db.WithContext(ctx).Model(&biz.User{}).
 		Where("id = ?", id).
 		Update("password_hash", passwordHash).Error; err != nil {
-		r.log.WithContext(ctx).Errorf("Failed to update password: %v", err)
+		r.log.WithContext(ctx).Errorf("Failed to rehash password: %v", err)
 		return err
 	}
 	return nil
@@ -75,3 +133,59 @@ func (r *userCommandRepo) UpdateLastLogin(ctx context.Context, id uuid.UUID, ip
 	return nil
 }
 
+func (r *userCommandRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Model(&biz.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", &now).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to mark email verified: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *userCommandRepo) IncrementFailedLogins(ctx context.Context, id uuid.UUID) (int, error) {
+	db := r.data.GetWriteDB()
+
+	// Single statement so concurrent failures are all counted. Not raw SQL, so the
+	// tenant scope keeps it to the users of the caller's tenant.
+	var users []*biz.User
+	if err := db.WithContext(ctx).Model(&users).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_login_attempts"}}}).
+		Where("id = ?", id).
+		UpdateColumn("failed_login_attempts", gorm.Expr("failed_login_attempts + 1")).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to increment failed logins: %v", err)
+		return 0, err
+	}
+
+	if len(users) == 0 {
+		return 0, nil
+	}
+	return users[0].FailedLoginAttempts, nil
+}
+
+func (r *userCommandRepo) LockUntil(ctx context.Context, id uuid.UUID, until time.Time) error {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Model(&biz.User{}).
+		Where("id = ?", id).
+		Update("locked_until", &until).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to lock user: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *userCommandRepo) ResetFailedLogins(ctx context.Context, id uuid.UUID) error {
+	db := r.data.GetWriteDB()
+	if err := db.WithContext(ctx).Model(&biz.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": 0,
+			"locked_until":          nil,
+		}).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to reset failed logins: %v", err)
+		return err
+	}
+	return nil
+}
@@ -141,3 +141,16 @@ func (r *userQueryRepo) Count(ctx context.Context, filter *biz.UserListFilter) (
 	return total, nil
 }
 
+func (r *userQueryRepo) ListPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	db := r.data.GetReadDB()
+	var hashes []string
+	if err := db.WithContext(ctx).Model(&biz.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error; err != nil {
+		r.log.WithContext(ctx).Errorf("Failed to list password history: %v", err)
+		return nil, err
+	}
+	return hashes, nil
+}
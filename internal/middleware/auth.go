@@ -2,13 +2,14 @@ package middleware
 
 import (
 	"context"
+	"slices"
 
 	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
 	"github.com/gofrs/uuid/v5"
 
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/middleware"
-	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/go-kratos/kratos/v2/transport"
 )
 
 // ContextKey for storing user info in context
@@ -18,15 +19,75 @@ const (
 	UserIDKey  contextKey = "user_id"
 	UserEmailKey contextKey = "user_email"
 	UserRoleKey contextKey = "user_role"
+	UserRolesKey contextKey = "user_roles"
+	PermissionsKey contextKey = "permissions"
+	SessionIDKey contextKey = "session_id"
+	TokenPurposeKey contextKey = "token_purpose"
+	// Set only on impersonation tokens: the admin acting as the user of UserIDKey
+	ImpersonatorIDKey contextKey = "impersonator_id"
+	ImpersonatorEmailKey contextKey = "impersonator_email"
 )
 
-// AuthMiddleware validates JWT token and adds user info to context
-func AuthMiddleware(jwtSecret []byte) middleware.Middleware {
+// SessionChecker reports whether the login session of an access token was revoked
+type SessionChecker interface {
+	IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+// AuthOption configures AuthMiddleware
+type AuthOption func(*authOptions)
+
+type authOptions struct {
+	sessionChecker      SessionChecker
+	apiKeyAuthenticator APIKeyAuthenticator
+	limitedTokens       map[string]map[string]bool // operation -> accepted challenge token purposes
+}
+
+// WithSessionChecker rejects access tokens whose session has been revoked
+// (logout, revoke-all, refresh token reuse)
+func WithSessionChecker(checker SessionChecker) AuthOption {
+	return func(o *authOptions) {
+		o.sessionChecker = checker
+	}
+}
+
+// WithLimitedToken accepts challenge tokens of a purpose (see jwt.GenerateChallengeToken)
+// for the given operations only, e.g. a "password_change" token for the change-password RPC
+func WithLimitedToken(purpose string, operations ...string) AuthOption {
+	return func(o *authOptions) {
+		if o.limitedTokens == nil {
+			o.limitedTokens = make(map[string]map[string]bool)
+		}
+		for _, operation := range operations {
+			if o.limitedTokens[operation] == nil {
+				o.limitedTokens[operation] = make(map[string]bool)
+			}
+			o.limitedTokens[operation][purpose] = true
+		}
+	}
+}
+
+// AuthMiddleware validates JWT token (or API key, see WithAPIKeyAuthenticator)
+// and adds user info to context
+func AuthMiddleware(keys *jwt.KeySet, opts ...AuthOption) middleware.Middleware {
+	options := &authOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
-			// Extract token from HTTP request
-			if httpReq, ok := http.RequestFromServerContext(ctx); ok {
-				authHeader := httpReq.Header.Get("Authorization")
+			// Extract token from HTTP header or gRPC "authorization" metadata
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				// Machine clients authenticate with an API key instead
+				if apiKey := extractAPIKey(tr); apiKey != "" {
+					ctx, err := authenticateAPIKey(ctx, tr, apiKey, options.apiKeyAuthenticator)
+					if err != nil {
+						return nil, err
+					}
+					return handler(ctx, req)
+				}
+
+				authHeader := tr.RequestHeader().Get("Authorization")
 				if authHeader == "" {
 					return nil, errors.Unauthorized("UNAUTHORIZED", "missing authorization header")
 				}
@@ -37,7 +98,20 @@ func AuthMiddleware(jwtSecret []byte) middleware.Middleware {
 				}
 
 				// Validate token
-				claims, err := jwt.ValidateToken(token, jwtSecret)
+				claims, err := jwt.ValidateToken(token, keys)
+				if err == jwt.ErrInvalidToken && options.limitedTokens[tr.Operation()] != nil {
+					// Limited (challenge) tokens are only valid for their operations
+					if claims, err := validateLimitedToken(token, keys, options.limitedTokens[tr.Operation()]); err == nil {
+						ctx, err := withCredentialTenant(ctx, claims.TenantID)
+						if err != nil {
+							return nil, err
+						}
+						ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+						ctx = context.WithValue(ctx, TokenPurposeKey, claims.Purpose)
+						publishAuthContext(ctx)
+						return handler(ctx, req)
+					}
+				}
 				if err != nil {
 					if err == jwt.ErrExpiredToken {
 						return nil, errors.Unauthorized("TOKEN_EXPIRED", "token has expired")
@@ -45,22 +119,71 @@ func AuthMiddleware(jwtSecret []byte) middleware.Middleware {
 					return nil, errors.Unauthorized("TOKEN_INVALID", "invalid token")
 				}
 
+				// The tenant of the token wins over the X-Tenant-ID header
+				ctx, err = withCredentialTenant(ctx, claims.TenantID)
+				if err != nil {
+					return nil, err
+				}
+
+				// Reject tokens of revoked sessions before they expire. Impersonation
+				// tokens also end with the session of the impersonating admin.
+				if options.sessionChecker != nil && claims.SessionID != uuid.Nil {
+					sessions := []uuid.UUID{claims.SessionID}
+					if claims.Actor != nil && claims.Actor.SessionID != "" {
+						actorSessionID, err := uuid.FromString(claims.Actor.SessionID)
+						if err != nil {
+							return nil, errors.Unauthorized("TOKEN_INVALID", "invalid token")
+						}
+						sessions = append(sessions, actorSessionID)
+					}
+					for _, sessionID := range sessions {
+						revoked, err := options.sessionChecker.IsSessionRevoked(ctx, sessionID)
+						if err != nil {
+							return nil, errors.ServiceUnavailable("SESSION_CHECK_FAILED", "unable to verify session")
+						}
+						if revoked {
+							return nil, errors.Unauthorized("TOKEN_REVOKED", "token has been revoked")
+						}
+					}
+				}
+
 				// Add user info to context
 				ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
 				ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
 				ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+				ctx = context.WithValue(ctx, UserRolesKey, claims.Roles)
+				ctx = context.WithValue(ctx, PermissionsKey, claims.Permissions)
+				ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
+
+				// Impersonation: keep the real actor next to the impersonated user
+				if claims.Actor != nil {
+					actorID, err := uuid.FromString(claims.Actor.Subject)
+					if err != nil {
+						return nil, errors.Unauthorized("TOKEN_INVALID", "invalid token")
+					}
+					ctx = context.WithValue(ctx, ImpersonatorIDKey, actorID)
+					ctx = context.WithValue(ctx, ImpersonatorEmailKey, claims.Actor.Email)
+				}
 
+				publishAuthContext(ctx)
 				return handler(ctx, req)
 			}
 
-			// For gRPC, token might be in metadata
-			// This is a simplified version, can be extended for gRPC
-			return handler(ctx, req)
+			return nil, errors.Unauthorized("UNAUTHORIZED", "missing authorization header")
 		}
 	}
 }
 
-// RequireRole middleware checks if user has required role
+func validateLimitedToken(token string, keys *jwt.KeySet, purposes map[string]bool) (*jwt.Claims, error) {
+	accepted := make([]string, 0, len(purposes))
+	for purpose := range purposes {
+		accepted = append(accepted, purpose)
+	}
+	return jwt.ValidateChallengeToken(token, keys, accepted...)
+}
+
+// RequireRole middleware checks if user has one of the required roles.
+// Prefer RequirePermission, roles are only named sets of permissions.
 func RequireRole(roles ...string) middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
@@ -68,11 +191,12 @@ func RequireRole(roles ...string) middleware.Middleware {
 			if !ok {
 				return nil, errors.Unauthorized("UNAUTHORIZED", "user role not found")
 			}
+			userRoles := append([]string{userRole}, GetUserRolesFromContext(ctx)...)
 
-			// Check if user role is in allowed roles
+			// Check if any user role is in allowed roles
 			allowed := false
 			for _, role := range roles {
-				if userRole == role {
+				if slices.Contains(userRoles, role) {
 					allowed = true
 					break
 				}
@@ -99,3 +223,43 @@ func GetUserRoleFromContext(ctx context.Context) (string, bool) {
 	return role, ok
 }
 
+// GetUserEmailFromContext extracts the user email from context
+func GetUserEmailFromContext(ctx context.Context) (string, bool) {
+	email, ok := ctx.Value(UserEmailKey).(string)
+	return email, ok
+}
+
+// GetImpersonatorIDFromContext returns the admin impersonating the current user,
+// ok is false for normal sessions
+func GetImpersonatorIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	actorID, ok := ctx.Value(ImpersonatorIDKey).(uuid.UUID)
+	return actorID, ok
+}
+
+// IsImpersonated reports whether the request uses an impersonation token
+func IsImpersonated(ctx context.Context) bool {
+	_, ok := GetImpersonatorIDFromContext(ctx)
+	return ok
+}
+
+// GetActorIDFromContext returns who really performs the request: the impersonating
+// admin during impersonation, otherwise the authenticated user
+func GetActorIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	if actorID, ok := GetImpersonatorIDFromContext(ctx); ok {
+		return actorID, true
+	}
+	return GetUserIDFromContext(ctx)
+}
+
+// GetTokenPurposeFromContext returns the purpose of the limited token of the request,
+// empty for access tokens and API keys
+func GetTokenPurposeFromContext(ctx context.Context) string {
+	purpose, _ := ctx.Value(TokenPurposeKey).(string)
+	return purpose
+}
+
+// GetSessionIDFromContext extracts the session id of the current access token from context
+func GetSessionIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	sessionID, ok := ctx.Value(SessionIDKey).(uuid.UUID)
+	return sessionID, ok && sessionID != uuid.Nil
+}
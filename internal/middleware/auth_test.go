@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/gofrs/uuid/v5"
+)
+
+// testTransport is a server transport carrying only request headers
+type testTransport struct {
+	header http.Header
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "/user.v1.UserService/GetUser" }
+func (t *testTransport) RequestHeader() transport.Header { return headerCarrier(t.header) }
+func (t *testTransport) ReplyHeader() transport.Header   { return headerCarrier(http.Header{}) }
+
+type headerCarrier http.Header
+
+func (h headerCarrier) Get(key string) string      { return http.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h headerCarrier) Keys() []string             { return nil }
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+
+// revokedSessions is a SessionChecker backed by a set
+type revokedSessions map[uuid.UUID]bool
+
+func (r revokedSessions) IsSessionRevoked(_ context.Context, sessionID uuid.UUID) (bool, error) {
+	return r[sessionID], nil
+}
+
+func TestAuthMiddlewareEndsImpersonationWithItsSessions(t *testing.T) {
+	keys := jwt.NewHMACKeySet([]byte("test-secret"))
+	adminSession, impersonationSession := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	actor := jwt.Actor{Subject: adminID.String(), Email: "admin@example.com", SessionID: adminSession.String()}
+	token, err := jwt.GenerateImpersonationToken(ownerID, "an@example.com", jwt.Grants{Role: "user"}, actor,
+		impersonationSession, keys, 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		revoked    revokedSessions
+		wantReason string
+	}{
+		{"live", revokedSessions{}, ""},
+		{"impersonation ended", revokedSessions{impersonationSession: true}, "TOKEN_REVOKED"},
+		{"admin session revoked", revokedSessions{adminSession: true}, "TOKEN_REVOKED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got context.Context
+			handler := AuthMiddleware(keys, WithSessionChecker(tt.revoked))(func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = ctx
+				return nil, nil
+			})
+			header := http.Header{"Authorization": []string{"Bearer " + token}}
+			ctx := transport.NewServerContext(context.Background(), &testTransport{header: header})
+
+			_, err := handler(ctx, nil)
+			if reason := errors.Reason(err); reason != tt.wantReason {
+				t.Fatalf("err = %v, want reason %q", err, tt.wantReason)
+			}
+			if tt.wantReason != "" {
+				return
+			}
+			if impersonator, ok := GetImpersonatorIDFromContext(got); !ok || impersonator != adminID {
+				t.Fatalf("impersonator = %v, want %v", impersonator, adminID)
+			}
+			if sessionID, _ := GetSessionIDFromContext(got); sessionID != impersonationSession {
+				t.Fatalf("session = %v, want the impersonation's own session", sessionID)
+			}
+		})
+	}
+}
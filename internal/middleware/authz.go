@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos-layout/api/authz"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// AuthzPolicy is the access policy of every rpc, declared with the (authz.rule)
+// method option in api/*/v1/*.proto. Operations are named like "/user.v1.UserService/GetUser",
+// the same on HTTP and gRPC.
+type AuthzPolicy struct {
+	rules map[string]*authz.Rule
+}
+
+// NewAuthzPolicy reads the rules of every rpc of the services (full names, e.g. "user.v1.UserService")
+// from the registered proto descriptors. It fails if an rpc has no rule or an inconsistent one,
+// so a new rpc cannot be served without a policy.
+func NewAuthzPolicy(services ...string) (*AuthzPolicy, error) {
+	p := &AuthzPolicy{rules: make(map[string]*authz.Rule)}
+	for _, name := range services {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("authz: service %s: %v", name, err)
+		}
+		service, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("authz: %s is not a service", name)
+		}
+
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			operation := fmt.Sprintf("/%s/%s", service.FullName(), method.Name())
+			rule, err := methodRule(method)
+			if err != nil {
+				return nil, fmt.Errorf("authz: rpc %s: %v", operation, err)
+			}
+			p.rules[operation] = rule
+		}
+	}
+	return p, nil
+}
+
+func methodRule(method protoreflect.MethodDescriptor) (*authz.Rule, error) {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	if !ok || !proto.HasExtension(opts, authz.E_Rule) {
+		return nil, fmt.Errorf("no (authz.rule) option declared")
+	}
+	rule, _ := proto.GetExtension(opts, authz.E_Rule).(*authz.Rule)
+	switch {
+	case rule == nil:
+		return nil, fmt.Errorf("no (authz.rule) option declared")
+	case rule.Public && (rule.Authenticated || len(rule.Permissions) > 0):
+		return nil, fmt.Errorf("a public rule cannot require authentication or permissions")
+	case !rule.Public && !rule.Authenticated && len(rule.Permissions) == 0:
+		return nil, fmt.Errorf("empty (authz.rule), set public, authenticated or permissions")
+	}
+	return rule, nil
+}
+
+// CheckOperations fails if an operation has no rule, e.g. an rpc of a service
+// that was registered with a server but not passed to NewAuthzPolicy.
+func (p *AuthzPolicy) CheckOperations(operations ...string) error {
+	for _, operation := range operations {
+		if _, ok := p.rules[operation]; !ok {
+			return fmt.Errorf("authz: rpc %s has no policy", operation)
+		}
+	}
+	return nil
+}
+
+// RequiresAuth reports whether an operation needs a valid token.
+// Operations without a rule are never public.
+func (p *AuthzPolicy) RequiresAuth(ctx context.Context, operation string) bool {
+	rule, ok := p.rules[operation]
+	return !ok || !rule.Public
+}
+
+// Permissions returns the permissions each operation needs, for RequirePermission
+func (p *AuthzPolicy) Permissions() map[string][]string {
+	permissions := make(map[string][]string, len(p.rules))
+	for operation, rule := range p.rules {
+		if len(rule.Permissions) > 0 {
+			permissions[operation] = rule.Permissions
+		}
+	}
+	return permissions
+}
@@ -9,11 +9,29 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
+// authContextKey holds the *authContext of a request being logged
+const authContextKey contextKey = "auth_context"
+
+// authContext receives the context built by AuthMiddleware, which runs after
+// LoggingMiddleware, so the request log can include the authenticated identity
+type authContext struct {
+	ctx context.Context
+}
+
+// publishAuthContext hands an authenticated context to LoggingMiddleware
+func publishAuthContext(ctx context.Context) {
+	if holder, ok := ctx.Value(authContextKey).(*authContext); ok {
+		holder.ctx = ctx
+	}
+}
+
 // LoggingMiddleware logs HTTP requests with detailed information
 func LoggingMiddleware(logger log.Logger) middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
 			startTime := time.Now()
+			holder := &authContext{}
+			ctx = context.WithValue(ctx, authContextKey, holder)
 
 			// Extract HTTP request information
 			var method, path, ip, userAgent string
@@ -27,14 +45,19 @@ func LoggingMiddleware(logger log.Logger) middleware.Middleware {
 			// Get request ID
 			requestID, _ := GetRequestIDFromContext(ctx)
 
-			// Get user context if available
-			userID, hasUserID := GetUserIDFromContext(ctx)
-			userEmail, hasUserEmail := ctx.Value(UserEmailKey).(string)
-			userRole, hasUserRole := GetUserRoleFromContext(ctx)
-
 			// Execute handler
 			resp, err := handler(ctx, req)
 
+			// Get user context if available
+			userCtx := ctx
+			if holder.ctx != nil {
+				userCtx = holder.ctx
+			}
+			userID, hasUserID := GetUserIDFromContext(userCtx)
+			userEmail, hasUserEmail := userCtx.Value(UserEmailKey).(string)
+			userRole, hasUserRole := GetUserRoleFromContext(userCtx)
+			impersonatorID, isImpersonated := GetImpersonatorIDFromContext(userCtx)
+
 			// Calculate duration
 			duration := time.Since(startTime)
 			durationMs := duration.Milliseconds()
@@ -71,6 +94,11 @@ func LoggingMiddleware(logger log.Logger) middleware.Middleware {
 			if hasUserRole {
 				keyvals = append(keyvals, "user_role", userRole)
 			}
+			// The real actor of impersonated requests
+			if isImpersonated {
+				impersonatorEmail, _ := userCtx.Value(ImpersonatorEmailKey).(string)
+				keyvals = append(keyvals, "actor_id", impersonatorID.String(), "actor_email", impersonatorEmail, "impersonated", true)
+			}
 
 			// Log based on status code
 			if statusCode >= 500 {
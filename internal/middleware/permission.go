@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// RequirePermission rejects callers that lack a permission required by the operation.
+// permissions maps operation names to the permissions they need (all of them);
+// operations that are not listed need none. Use it after AuthMiddleware.
+func RequirePermission(permissions map[string][]string) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "missing transport")
+			}
+			for _, permission := range permissions[tr.Operation()] {
+				if !HasPermission(ctx, permission) {
+					return nil, errors.Forbidden("FORBIDDEN", "insufficient permissions").
+						WithMetadata(map[string]string{"permission": permission})
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// HasPermission reports whether the caller's token grants the permission
+func HasPermission(ctx context.Context, permission string) bool {
+	return slices.Contains(GetPermissionsFromContext(ctx), permission)
+}
+
+// GetPermissionsFromContext returns the permissions granted to the caller
+func GetPermissionsFromContext(ctx context.Context) []string {
+	permissions, _ := ctx.Value(PermissionsKey).([]string)
+	return permissions
+}
+
+// GetUserRolesFromContext returns every role of the caller
+func GetUserRolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(UserRolesKey).([]string)
+	return roles
+}
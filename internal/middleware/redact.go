@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos-layout/api/authz"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RedactionPolicy clears the fields of responses that the caller may not see, declared with
+// the (authz.redact) field option and the (authz.owner) message option in api/*/v1/*.proto.
+// It works on the proto replies, so HTTP JSON and gRPC responses are redacted the same way.
+type RedactionPolicy struct {
+	messages map[protoreflect.FullName]*messageRedaction
+}
+
+// messageRedaction is the policy of one message type
+type messageRedaction struct {
+	owner  protoreflect.FieldDescriptor // Field holding the owner's user id, nil if none
+	fields []fieldRedaction
+	nested []protoreflect.FieldDescriptor // Message fields whose type has redacted fields
+}
+
+type fieldRedaction struct {
+	field       protoreflect.FieldDescriptor
+	permissions []string // Any of them lets the caller see the field
+}
+
+// NewRedactionPolicy reads the redaction rules of every message returned by the services
+// (full names, e.g. "user.v1.UserService") from the registered proto descriptors.
+// It fails on a rule that can never be satisfied or an owner option naming no string field.
+func NewRedactionPolicy(services ...string) (*RedactionPolicy, error) {
+	p := &RedactionPolicy{messages: make(map[protoreflect.FullName]*messageRedaction)}
+	loading := make(map[protoreflect.FullName]bool)
+	for _, name := range services {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("redact: service %s: %v", name, err)
+		}
+		service, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("redact: %s is not a service", name)
+		}
+
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			if _, err := p.load(methods.Get(i).Output(), loading); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+// load builds the policy of a message and of the messages it contains,
+// it reports whether replies of the message type need to be walked at all
+func (p *RedactionPolicy) load(md protoreflect.MessageDescriptor, loading map[protoreflect.FullName]bool) (bool, error) {
+	if _, ok := p.messages[md.FullName()]; ok {
+		return true, nil
+	}
+	if done, ok := loading[md.FullName()]; ok {
+		// Recursive message types are walked if they turn out to need it
+		return !done, nil
+	}
+	loading[md.FullName()] = false
+	defer func() { loading[md.FullName()] = true }()
+
+	r := &messageRedaction{}
+	owner, err := messageOwner(md)
+	if err != nil {
+		return false, fmt.Errorf("redact: message %s: %v", md.FullName(), err)
+	}
+	r.owner = owner
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if rule := fieldRule(field); rule != nil {
+			if len(rule.Permissions) == 0 && owner == nil {
+				return false, fmt.Errorf("redact: field %s: no permission and no (authz.owner), nobody could see it", field.FullName())
+			}
+			r.fields = append(r.fields, fieldRedaction{field: field, permissions: rule.Permissions})
+		}
+
+		value := field
+		if field.IsMap() {
+			value = field.MapValue()
+		}
+		if value.Message() == nil {
+			continue
+		}
+		needed, err := p.load(value.Message(), loading)
+		if err != nil {
+			return false, err
+		}
+		if needed {
+			r.nested = append(r.nested, field)
+		}
+	}
+
+	if len(r.fields) == 0 && len(r.nested) == 0 {
+		return false, nil
+	}
+	p.messages[md.FullName()] = r
+	return true, nil
+}
+
+func messageOwner(md protoreflect.MessageDescriptor) (protoreflect.FieldDescriptor, error) {
+	opts, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || !proto.HasExtension(opts, authz.E_Owner) {
+		return nil, nil
+	}
+	name, _ := proto.GetExtension(opts, authz.E_Owner).(string)
+	field := md.Fields().ByName(protoreflect.Name(name))
+	if field == nil || field.Kind() != protoreflect.StringKind || field.Cardinality() == protoreflect.Repeated {
+		return nil, fmt.Errorf("(authz.owner) %q is not a string field", name)
+	}
+	return field, nil
+}
+
+func fieldRule(field protoreflect.FieldDescriptor) *authz.Redact {
+	opts, ok := field.Options().(*descriptorpb.FieldOptions)
+	if !ok || !proto.HasExtension(opts, authz.E_Redact) {
+		return nil
+	}
+	rule, _ := proto.GetExtension(opts, authz.E_Redact).(*authz.Redact)
+	return rule
+}
+
+// Redact clears, in place, every field of msg (and of the messages it contains) that the caller may not see
+func (p *RedactionPolicy) Redact(ctx context.Context, msg proto.Message) {
+	if msg == nil {
+		return
+	}
+	p.redact(ctx, msg.ProtoReflect())
+}
+
+func (p *RedactionPolicy) redact(ctx context.Context, m protoreflect.Message) {
+	r, ok := p.messages[m.Descriptor().FullName()]
+	if !ok || !m.IsValid() {
+		return
+	}
+
+	if len(r.fields) > 0 && !isOwner(ctx, m, r.owner) {
+		for _, f := range r.fields {
+			if !hasAnyPermission(ctx, f.permissions) {
+				m.Clear(f.field)
+			}
+		}
+	}
+
+	for _, field := range r.nested {
+		switch {
+		case field.IsList():
+			list := m.Get(field).List()
+			for i := 0; i < list.Len(); i++ {
+				p.redact(ctx, list.Get(i).Message())
+			}
+		case field.IsMap():
+			m.Get(field).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				p.redact(ctx, v.Message())
+				return true
+			})
+		case m.Has(field):
+			p.redact(ctx, m.Get(field).Message())
+		}
+	}
+}
+
+// isOwner reports whether the caller is the owner of the record m.
+// During impersonation the owner is the impersonated user.
+func isOwner(ctx context.Context, m protoreflect.Message, owner protoreflect.FieldDescriptor) bool {
+	if owner == nil {
+		return false
+	}
+	userID, ok := GetUserIDFromContext(ctx)
+	return ok && m.Get(owner).String() == userID.String()
+}
+
+func hasAnyPermission(ctx context.Context, permissions []string) bool {
+	for _, permission := range permissions {
+		if HasPermission(ctx, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactResponse applies the redaction policy to every reply. Use it after
+// AuthMiddleware so the caller is known; replies to anonymous callers are fully redacted.
+func RedactResponse(policy *RedactionPolicy) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req)
+			if err != nil {
+				return reply, err
+			}
+			if msg, ok := reply.(proto.Message); ok {
+				policy.Redact(ctx, msg)
+			}
+			return reply, nil
+		}
+	}
+}
@@ -0,0 +1,512 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos-layout/api/authz"
+	provincev1 "github.com/go-kratos/kratos-layout/api/province/v1"
+	userv1 "github.com/go-kratos/kratos-layout/api/user/v1"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	ownerID  = uuid.Must(uuid.FromString("0b6f0c6e-7a43-4c1e-9d55-0f2f5c3f6a01"))
+	otherID  = uuid.Must(uuid.FromString("5d1c8f3a-2b7e-4f60-8c19-6a4e0d9b7c02"))
+	adminID  = uuid.Must(uuid.FromString("9e2a4b6c-1d3f-4a5b-8c7d-2e4f6a8b0c03"))
+	callerID = uuid.Must(uuid.FromString("c4d5e6f7-0a1b-4c2d-9e3f-5a6b7c8d9e04"))
+)
+
+// caller builds the context AuthMiddleware leaves for a token
+func caller(userID uuid.UUID, permissions ...string) context.Context {
+	ctx := context.WithValue(context.Background(), UserIDKey, userID)
+	return context.WithValue(ctx, PermissionsKey, permissions)
+}
+
+// impersonating is the context of admin acting as userID with the user's own permissions
+func impersonating(admin, userID uuid.UUID, permissions ...string) context.Context {
+	return context.WithValue(caller(userID, permissions...), ImpersonatorIDKey, admin)
+}
+
+func newTestPolicy(t *testing.T, services ...string) *RedactionPolicy {
+	t.Helper()
+	policy, err := NewRedactionPolicy(services...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policy
+}
+
+func testUser(id uuid.UUID) *userv1.User {
+	return &userv1.User{
+		Id:          id.String(),
+		Email:       "an@example.com",
+		Username:    "an",
+		FullName:    "Nguyen Van An",
+		DateOfBirth: "1990-05-17",
+		LastLoginAt: "2026-10-16T08:30:00Z",
+		LastLoginIp: "203.0.113.25",
+		Role:        "user",
+	}
+}
+
+// userPrivate reports whether the private fields of u survived redaction
+func userPrivate(t *testing.T, u *userv1.User) bool {
+	t.Helper()
+	visible := u.DateOfBirth != ""
+	if visible != (u.LastLoginAt != "") || visible != (u.LastLoginIp != "") {
+		t.Fatalf("private fields were redacted unevenly: %v", u)
+	}
+	return visible
+}
+
+func TestRedactUser(t *testing.T) {
+	policy := newTestPolicy(t, userv1.UserService_ServiceDesc.ServiceName)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantPrivate bool
+	}{
+		{"anonymous", context.Background(), false},
+		{"another user", caller(otherID), false},
+		{"another user with unrelated permissions", caller(otherID, "user:read", "audit:read"), false},
+		{"owner", caller(ownerID), true},
+		{"permission holder", caller(otherID, "user:read_sensitive"), true},
+		// The impersonating admin sees what the impersonated user sees, not more
+		{"impersonating the owner", impersonating(adminID, ownerID), true},
+		{"impersonating another user", impersonating(adminID, otherID), false},
+		{"impersonating a permission holder", impersonating(adminID, otherID, "user:read_sensitive"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := &userv1.GetUserResponse{User: testUser(ownerID)}
+			policy.Redact(tt.ctx, reply)
+
+			if got := userPrivate(t, reply.User); got != tt.wantPrivate {
+				t.Fatalf("private fields visible = %v, want %v: %v", got, tt.wantPrivate, reply.User)
+			}
+			if reply.User.Email == "" || reply.User.Username == "" || reply.User.FullName == "" || reply.User.Role == "" {
+				t.Fatalf("public fields were redacted: %v", reply.User)
+			}
+		})
+	}
+}
+
+func TestRedactUserList(t *testing.T) {
+	policy := newTestPolicy(t, userv1.UserService_ServiceDesc.ServiceName)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []bool // Private fields visible per user of the list
+	}{
+		{"anonymous", context.Background(), []bool{false, false, false}},
+		{"owner sees only their own record", caller(ownerID), []bool{true, false, false}},
+		{"permission holder", caller(callerID, "user:read_sensitive"), []bool{true, true, true}},
+		{"impersonating the owner", impersonating(adminID, ownerID), []bool{true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := &userv1.ListUsersResponse{
+				Users: []*userv1.User{testUser(ownerID), testUser(otherID), testUser(adminID)},
+				Total: 3,
+			}
+			policy.Redact(tt.ctx, reply)
+
+			for i, user := range reply.Users {
+				if got := userPrivate(t, user); got != tt.want[i] {
+					t.Errorf("users[%d] private fields visible = %v, want %v", i, got, tt.want[i])
+				}
+			}
+			if reply.Total != 3 {
+				t.Fatalf("total = %d, want 3", reply.Total)
+			}
+		})
+	}
+}
+
+func TestRedactProvince(t *testing.T) {
+	policy := newTestPolicy(t, provincev1.ProvinceService_ServiceDesc.ServiceName)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantAudit bool
+	}{
+		{"anonymous", context.Background(), false},
+		{"another user", caller(otherID, "province:read"), false},
+		{"creator", caller(ownerID), true},
+		{"audit reader", caller(otherID, "audit:read"), true},
+		{"impersonating the creator", impersonating(adminID, ownerID), true},
+		{"impersonating another user", impersonating(adminID, otherID), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := &provincev1.ListProvincesResponse{
+				Provinces: []*provincev1.Province{{
+					Id:        "79",
+					Code:      "HCM",
+					Name:      "Thành phố Hồ Chí Minh",
+					CreatedBy: ownerID.String(),
+					UpdatedBy: adminID.String(),
+				}},
+				Total: 1,
+			}
+			policy.Redact(tt.ctx, reply)
+
+			province := reply.Provinces[0]
+			if got := province.CreatedBy != "" && province.UpdatedBy != ""; got != tt.wantAudit {
+				t.Fatalf("audit fields visible = %v, want %v: %v", got, tt.wantAudit, province)
+			}
+			if province.Code != "HCM" || province.Name == "" {
+				t.Fatalf("public fields were redacted: %v", province)
+			}
+		})
+	}
+
+	t.Run("get reply", func(t *testing.T) {
+		reply := &provincev1.GetProvinceResponse{Province: &provincev1.Province{Id: "79", CreatedBy: ownerID.String()}}
+		policy.Redact(context.Background(), reply)
+		if reply.Province.CreatedBy != "" {
+			t.Fatalf("created_by = %q, want it redacted", reply.Province.CreatedBy)
+		}
+	})
+}
+
+func TestRedactResponse(t *testing.T) {
+	policy := newTestPolicy(t, userv1.UserService_ServiceDesc.ServiceName)
+	handler := RedactResponse(policy)(func(context.Context, interface{}) (interface{}, error) {
+		return &userv1.GetUserResponse{User: testUser(ownerID)}, nil
+	})
+
+	reply, err := handler(caller(otherID), &userv1.GetUserRequest{Id: ownerID.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user := reply.(*userv1.GetUserResponse).User; userPrivate(t, user) {
+		t.Fatalf("reply to another user was not redacted: %v", user)
+	}
+}
+
+// Descriptors built at test time cover shapes the api protos do not have
+
+func stringField(name string, number int32, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:    proto.String(name),
+		Number:  proto.Int32(number),
+		Label:   descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:    descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		Options: opts,
+	}
+}
+
+func messageField(name string, number int32, typeName string, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    label.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(typeName),
+	}
+}
+
+func redactOption(permissions ...string) *descriptorpb.FieldOptions {
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, authz.E_Redact, &authz.Redact{Permissions: permissions})
+	return opts
+}
+
+func ownerOption(field string) *descriptorpb.MessageOptions {
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, authz.E_Owner, field)
+	return opts
+}
+
+// registerTestService registers a file of the given package declaring the messages and a
+// service returning the first one, and returns the service name
+func registerTestService(t *testing.T, pkg string, messages ...*descriptorpb.DescriptorProto) string {
+	t.Helper()
+	service := pkg + ".TestService"
+	if _, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service)); err == nil {
+		return service // Registered by an earlier run of the test (-count)
+	}
+
+	reply := "." + pkg + "." + messages[0].GetName()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String(strings.ReplaceAll(pkg, ".", "/") + "/test.proto"),
+		Package:     proto.String(pkg),
+		Syntax:      proto.String("proto3"),
+		MessageType: messages,
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("TestService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Get"),
+				InputType:  proto.String(reply),
+				OutputType: proto.String(reply),
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(file); err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// registerNodeService registers a recursive Node message, owned by owner_id, with a
+// permission-guarded and an owner-only field, reached through a single field, a list and a map
+func registerNodeService(t *testing.T) (string, protoreflect.MessageDescriptor) {
+	t.Helper()
+	const pkg = "redacttest.node.v1"
+	node := "." + pkg + ".Node"
+	service := registerTestService(t, pkg, &descriptorpb.DescriptorProto{
+		Name:    proto.String("Node"),
+		Options: ownerOption("owner_id"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			stringField("owner_id", 1, nil),
+			stringField("secret", 2, redactOption("secret:read")),
+			stringField("note", 3, redactOption()),
+			messageField("parent", 4, node, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+			messageField("children", 5, node, descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+			messageField("by_name", 6, node+".ByNameEntry", descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+		},
+		NestedType: []*descriptorpb.DescriptorProto{{
+			Name:    proto.String("ByNameEntry"),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			Field: []*descriptorpb.FieldDescriptorProto{
+				stringField("key", 1, nil),
+				messageField("value", 2, node, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+			},
+		}},
+	})
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(pkg + ".Node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service, desc.(protoreflect.MessageDescriptor)
+}
+
+func newNode(md protoreflect.MessageDescriptor, owner uuid.UUID) *dynamicpb.Message {
+	m := dynamicpb.NewMessage(md)
+	fields := md.Fields()
+	m.Set(fields.ByName("owner_id"), protoreflect.ValueOfString(owner.String()))
+	m.Set(fields.ByName("secret"), protoreflect.ValueOfString("secret of "+owner.String()))
+	m.Set(fields.ByName("note"), protoreflect.ValueOfString("note of "+owner.String()))
+	return m
+}
+
+// visible reports whether the secret and the owner-only note of a node survived redaction
+func visible(m protoreflect.Message) (secret, note bool) {
+	fields := m.Descriptor().Fields()
+	return m.Has(fields.ByName("secret")), m.Has(fields.ByName("note"))
+}
+
+func TestRedactWalksNestedListsMapsAndRecursiveTypes(t *testing.T) {
+	service, md := registerNodeService(t)
+	policy := newTestPolicy(t, service)
+	fields := md.Fields()
+
+	// root (owner) -> parent (other) -> parent (owner), root.children = [owner, other],
+	// root.by_name = {owner, other}
+	build := func() (root *dynamicpb.Message, nodes map[string]protoreflect.Message) {
+		root = newNode(md, ownerID)
+		parent := newNode(md, otherID)
+		grandparent := newNode(md, ownerID)
+		parent.Set(fields.ByName("parent"), protoreflect.ValueOfMessage(grandparent))
+		root.Set(fields.ByName("parent"), protoreflect.ValueOfMessage(parent))
+
+		children := root.Mutable(fields.ByName("children")).List()
+		ownChild, otherChild := newNode(md, ownerID), newNode(md, otherID)
+		children.Append(protoreflect.ValueOfMessage(ownChild))
+		children.Append(protoreflect.ValueOfMessage(otherChild))
+
+		byName := root.Mutable(fields.ByName("by_name")).Map()
+		ownEntry, otherEntry := newNode(md, ownerID), newNode(md, otherID)
+		byName.Set(protoreflect.ValueOfString("own").MapKey(), protoreflect.ValueOfMessage(ownEntry))
+		byName.Set(protoreflect.ValueOfString("other").MapKey(), protoreflect.ValueOfMessage(otherEntry))
+
+		return root, map[string]protoreflect.Message{
+			"root":        root,
+			"parent":      parent,
+			"grandparent": grandparent,
+			"own child":   ownChild,
+			"other child": otherChild,
+			"own entry":   ownEntry,
+			"other entry": otherEntry,
+		}
+	}
+
+	owned := map[string]bool{"root": true, "grandparent": true, "own child": true, "own entry": true}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantSecret func(node string) bool
+		wantNote   func(node string) bool
+	}{
+		{
+			name:       "anonymous",
+			ctx:        context.Background(),
+			wantSecret: func(string) bool { return false },
+			wantNote:   func(string) bool { return false },
+		},
+		{
+			name:       "owner",
+			ctx:        caller(ownerID),
+			wantSecret: func(node string) bool { return owned[node] },
+			wantNote:   func(node string) bool { return owned[node] },
+		},
+		{
+			// The permission does not open the owner-only field
+			name:       "permission holder",
+			ctx:        caller(callerID, "secret:read"),
+			wantSecret: func(string) bool { return true },
+			wantNote:   func(string) bool { return false },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, nodes := build()
+			policy.Redact(tt.ctx, root)
+
+			for name, node := range nodes {
+				secret, note := visible(node)
+				if secret != tt.wantSecret(name) || note != tt.wantNote(name) {
+					t.Errorf("%s: secret visible = %v, note visible = %v, want %v, %v",
+						name, secret, note, tt.wantSecret(name), tt.wantNote(name))
+				}
+				if !node.Has(fields.ByName("owner_id")) {
+					t.Errorf("%s: owner_id was redacted", name)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRedactionPolicySkipsMessagesWithoutRules(t *testing.T) {
+	service := registerTestService(t, "redacttest.plain.v1",
+		&descriptorpb.DescriptorProto{
+			Name: proto.String("Reply"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				stringField("name", 1, nil),
+				messageField("item", 2, ".redacttest.plain.v1.Item", descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+			},
+		},
+		&descriptorpb.DescriptorProto{
+			Name:  proto.String("Item"),
+			Field: []*descriptorpb.FieldDescriptorProto{stringField("name", 1, nil)},
+		},
+	)
+
+	policy := newTestPolicy(t, service)
+	if len(policy.messages) != 0 {
+		t.Fatalf("policy walks %d message types, want none", len(policy.messages))
+	}
+}
+
+func TestNewRedactionPolicyRejectsUnsatisfiableRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     string
+		message *descriptorpb.DescriptorProto
+		wantErr string
+	}{
+		{
+			name: "no permission and no owner",
+			pkg:  "redacttest.nobody.v1",
+			message: &descriptorpb.DescriptorProto{
+				Name:  proto.String("Reply"),
+				Field: []*descriptorpb.FieldDescriptorProto{stringField("secret", 1, redactOption())},
+			},
+			wantErr: "nobody could see it",
+		},
+		{
+			name: "owner names a missing field",
+			pkg:  "redacttest.missingowner.v1",
+			message: &descriptorpb.DescriptorProto{
+				Name:    proto.String("Reply"),
+				Options: ownerOption("owner_id"),
+				Field:   []*descriptorpb.FieldDescriptorProto{stringField("secret", 1, redactOption())},
+			},
+			wantErr: `(authz.owner) "owner_id" is not a string field`,
+		},
+		{
+			name: "owner names a non-string field",
+			pkg:  "redacttest.intowner.v1",
+			message: &descriptorpb.DescriptorProto{
+				Name:    proto.String("Reply"),
+				Options: ownerOption("owner_id"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:   proto.String("owner_id"),
+						Number: proto.Int32(1),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+					},
+					stringField("secret", 2, redactOption()),
+				},
+			},
+			wantErr: `(authz.owner) "owner_id" is not a string field`,
+		},
+		{
+			name: "owner names a repeated field",
+			pkg:  "redacttest.repeatedowner.v1",
+			message: &descriptorpb.DescriptorProto{
+				Name:    proto.String("Reply"),
+				Options: ownerOption("owner_ids"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:   proto.String("owner_ids"),
+						Number: proto.Int32(1),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					},
+					stringField("secret", 2, redactOption()),
+				},
+			},
+			wantErr: `(authz.owner) "owner_ids" is not a string field`,
+		},
+		{
+			// Rules deep inside a reply are checked too
+			name: "nested message",
+			pkg:  "redacttest.nested.v1",
+			message: &descriptorpb.DescriptorProto{
+				Name: proto.String("Reply"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					messageField("item", 1, ".redacttest.nested.v1.Reply.Item", descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name:  proto.String("Item"),
+					Field: []*descriptorpb.FieldDescriptorProto{stringField("secret", 1, redactOption())},
+				}},
+			},
+			wantErr: "redacttest.nested.v1.Reply.Item.secret: no permission and no (authz.owner)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := registerTestService(t, tt.pkg, tt.message)
+			_, err := NewRedactionPolicy(service)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("unknown service", func(t *testing.T) {
+		if _, err := NewRedactionPolicy("redacttest.v1.MissingService"); err == nil {
+			t.Fatal("want an error for an unknown service")
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/tenant"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// TenantChecker reports whether a tenant exists and is active
+type TenantChecker interface {
+	TenantExists(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// TenantOption configures TenantMiddleware
+type TenantOption func(*tenantOptions)
+
+type tenantOptions struct {
+	checker TenantChecker
+}
+
+// WithTenantChecker rejects requests naming an unknown or inactive tenant
+func WithTenantChecker(checker TenantChecker) TenantOption {
+	return func(o *tenantOptions) {
+		o.checker = checker
+	}
+}
+
+// TenantMiddleware scopes the request to the tenant of the X-Tenant-ID header (HTTP header
+// or gRPC metadata), used by requests without an access token: login, register and system clients.
+// Requests without the header belong to tenant.DefaultID. AuthMiddleware then takes the
+// tenant from the token and rejects a header naming another tenant.
+func TenantMiddleware(opts ...TenantOption) middleware.Middleware {
+	options := &tenantOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tenantID, ok, err := tenantFromHeader(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			if options.checker != nil {
+				exists, err := options.checker.TenantExists(ctx, tenantID)
+				if err != nil {
+					return nil, errors.ServiceUnavailable("TENANT_CHECK_FAILED", "unable to verify tenant")
+				}
+				if !exists {
+					return nil, errors.BadRequest("INVALID_TENANT", "unknown tenant")
+				}
+			}
+			return handler(tenant.NewContext(ctx, tenantID), req)
+		}
+	}
+}
+
+// tenantFromHeader returns the tenant named by the request, ok is false when it names none
+func tenantFromHeader(ctx context.Context) (uuid.UUID, bool, error) {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return uuid.Nil, false, nil
+	}
+	header := tr.RequestHeader().Get(tenant.Header)
+	if header == "" {
+		return uuid.Nil, false, nil
+	}
+	tenantID, err := uuid.FromString(header)
+	if err != nil || tenantID == uuid.Nil {
+		return uuid.Nil, false, errors.BadRequest("INVALID_TENANT", "invalid "+tenant.Header+" header")
+	}
+	return tenantID, true, nil
+}
+
+// withCredentialTenant scopes ctx to the tenant of a token or API key
+// (tenant.DefaultID when it carries none) and rejects a header naming another tenant
+func withCredentialTenant(ctx context.Context, tenantID uuid.UUID) (context.Context, error) {
+	if tenantID == uuid.Nil {
+		tenantID = tenant.DefaultID
+	}
+	if headerID, ok, _ := tenantFromHeader(ctx); ok && headerID != tenantID {
+		return nil, errors.Forbidden("TENANT_MISMATCH", "the credentials belong to another tenant")
+	}
+	return tenant.NewContext(ctx, tenantID), nil
+}
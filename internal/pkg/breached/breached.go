@@ -0,0 +1,226 @@
+// Package breached screens passwords against a local copy of the Have I Been
+// Pwned (HIBP) Pwned Passwords corpus, without any network access.
+//
+// Two layouts of the SHA-1 corpus are supported:
+//   - a single file of "<40 hex SHA-1>:<count>" lines (haveibeenpwned-downloader -s true)
+//   - a directory of range files named by the 5 hex prefix (e.g. "5BAA6.txt"), each
+//     with "<35 hex suffix>:<count>" lines, as returned by the range API
+//
+// Only the first 64 bits of each hash are kept, in a sorted slice searched with
+// binary search (8 bytes per hash). A false positive needs a 64-bit collision
+// with one of the loaded hashes, which is negligible for this use.
+//
+// Memory: the index is sized from the corpus size before loading, about 8 bytes per
+// line of the corpus (~7 GB for the full ~900M line corpus). With a minimum count
+// the skipped lines are released once loading is done.
+package breached
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	hashHexLength   = 40
+	prefixHexLength = 5
+
+	// Shortest corpus lines ("<hash>:<count>\n" with a one digit count), used to
+	// size the index from the file size before loading
+	minLineLength      = hashHexLength + 3
+	minRangeLineLength = hashHexLength - prefixHexLength + 3
+)
+
+// Index is an in-memory set of breached password hashes. It is read-only after
+// loading and safe for concurrent use.
+type Index struct {
+	hashes []uint64 // sorted
+}
+
+// Load reads "<SHA-1>:<count>" lines. Hashes seen fewer than minCount times are skipped.
+func Load(r io.Reader, minCount int64) (*Index, error) {
+	idx := &Index{}
+	if err := idx.read(r, "", minCount); err != nil {
+		return nil, err
+	}
+	idx.finish()
+	return idx, nil
+}
+
+// LoadPath loads a corpus file, or a directory of range files (see package doc)
+func LoadPath(path string, minCount int64) (*Index, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &Index{}
+	if !info.IsDir() {
+		idx.grow(info.Size(), minLineLength)
+		if err := idx.readFile(path, "", minCount); err != nil {
+			return nil, err
+		}
+		idx.finish()
+		return idx, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var rangeFiles []os.DirEntry
+	var size int64
+	for _, entry := range entries {
+		prefix := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if entry.IsDir() || len(prefix) != prefixHexLength || !isHex([]byte(prefix)) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		rangeFiles = append(rangeFiles, entry)
+		size += info.Size()
+	}
+
+	idx.grow(size, minRangeLineLength)
+	for _, entry := range rangeFiles {
+		prefix := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if err := idx.readFile(filepath.Join(path, entry.Name()), strings.ToUpper(prefix), minCount); err != nil {
+			return nil, err
+		}
+	}
+	idx.finish()
+	return idx, nil
+}
+
+// Len returns the number of loaded hashes
+func (idx *Index) Len() int {
+	return len(idx.hashes)
+}
+
+// Contains reports whether the password is in the corpus
+func (idx *Index) Contains(password string) bool {
+	if idx == nil || len(idx.hashes) == 0 {
+		return false
+	}
+	sum := sha1.Sum([]byte(password))
+	_, found := slices.BinarySearch(idx.hashes, binary.BigEndian.Uint64(sum[:8]))
+	return found
+}
+
+func (idx *Index) readFile(path, prefix string, minCount int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := idx.read(f, prefix, minCount); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// read parses lines of the hash (or, with a prefix, the hash suffix) and its count
+func (idx *Index) read(r io.Reader, prefix string, minCount int64) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	buf := make([]byte, 0, hashHexLength)
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 || text[0] == '#' {
+			continue
+		}
+
+		hash, countText, hasCount := bytes.Cut(text, []byte(":"))
+		buf = append(append(buf[:0], prefix...), hash...)
+		if len(buf) != hashHexLength || !isHex(buf) {
+			return fmt.Errorf("line %d: expected a SHA-1 hash", line)
+		}
+
+		if hasCount && minCount > 1 {
+			count, ok := parseCount(countText)
+			if !ok {
+				return fmt.Errorf("line %d: invalid count", line)
+			}
+			if count < minCount {
+				continue
+			}
+		}
+
+		idx.hashes = append(idx.hashes, hexUint64(buf[:16]))
+	}
+	return scanner.Err()
+}
+
+// grow reserves room for every line of size bytes of corpus, so loading never
+// copies the slice (append would briefly need twice the final size)
+func (idx *Index) grow(size int64, lineLength int) {
+	idx.hashes = slices.Grow(idx.hashes, int(size/int64(lineLength)))
+}
+
+// finish sorts the hashes; the corpus is usually sorted already
+func (idx *Index) finish() {
+	if !slices.IsSorted(idx.hashes) {
+		slices.Sort(idx.hashes)
+	}
+	idx.hashes = slices.Compact(idx.hashes)
+	if cap(idx.hashes) > len(idx.hashes)+len(idx.hashes)/4 {
+		// Release the room reserved for skipped lines (min count, duplicates, comments)
+		idx.hashes = slices.Clone(idx.hashes)
+	}
+	idx.hashes = slices.Clip(idx.hashes)
+}
+
+func isHex(b []byte) bool {
+	for _, c := range b {
+		if hexValue(c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10
+	}
+	return -1
+}
+
+// hexUint64 decodes 16 hex digits (already validated)
+func hexUint64(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<4 | uint64(hexValue(c))
+	}
+	return v
+}
+
+func parseCount(b []byte) (int64, bool) {
+	if len(b) == 0 || len(b) > 18 {
+		return 0, false
+	}
+	var n int64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int64(c-'0')
+	}
+	return n, true
+}
@@ -0,0 +1,126 @@
+// Package device derives a coarse device fingerprint for login history from
+// the user agent and client IP.
+//
+// The fingerprint only uses the browser and OS family (no versions, so updates
+// do not look like a new device) and the IP subnet (/24 for IPv4, /48 for IPv6,
+// so a new address from the same network is not a new device either).
+package device
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+)
+
+const (
+	ipv4PrefixBits = 24
+	ipv6PrefixBits = 48
+)
+
+// Device identifies where a login came from
+type Device struct {
+	Name        string // e.g. "Chrome on Windows"
+	Subnet      string // e.g. "203.0.113.0/24", empty if the IP is unknown
+	Fingerprint string // hex, stable for the same name and subnet
+}
+
+// Identify returns the device of a user agent and client IP
+func Identify(userAgent, ip string) Device {
+	name := Family(userAgent)
+	subnet := Subnet(ip)
+	sum := sha256.Sum256([]byte(name + "|" + subnet))
+	return Device{
+		Name:        name,
+		Subnet:      subnet,
+		Fingerprint: hex.EncodeToString(sum[:16]),
+	}
+}
+
+// Family returns "<browser> on <os>" for browsers, or the client name for other user agents
+func Family(userAgent string) string {
+	ua := strings.TrimSpace(userAgent)
+	if ua == "" {
+		return "Unknown"
+	}
+	browser := browserFamily(ua)
+	system := osFamily(ua)
+	if system == "" {
+		return browser
+	}
+	return browser + " on " + system
+}
+
+// Subnet returns the network of an IP address in CIDR notation
+func Subnet(ip string) string {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(ipv4PrefixBits, 32)), Mask: net.CIDRMask(ipv4PrefixBits, 32)}).String()
+	}
+	return (&net.IPNet{IP: parsed.Mask(net.CIDRMask(ipv6PrefixBits, 128)), Mask: net.CIDRMask(ipv6PrefixBits, 128)}).String()
+}
+
+// Order matters: Chromium based browsers also send "Chrome" and "Safari"
+var browsers = []struct {
+	token string
+	name  string
+}{
+	{"Edg/", "Edge"},
+	{"EdgA/", "Edge"},
+	{"EdgiOS/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Opera", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"YaBrowser/", "Yandex"},
+	{"coc_coc_browser/", "Coc Coc"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+	{"curl/", "curl"},
+	{"PostmanRuntime/", "Postman"},
+	{"okhttp/", "OkHttp"},
+	{"grpc-", "gRPC"},
+	{"Go-http-client/", "Go"},
+	{"python-requests/", "Python"},
+}
+
+func browserFamily(ua string) string {
+	for _, b := range browsers {
+		if strings.Contains(ua, b.token) {
+			return b.name
+		}
+	}
+	// First product token, e.g. "MyApp/1.2 (...)" -> "MyApp"
+	product, _, _ := strings.Cut(ua, " ")
+	product, _, _ = strings.Cut(product, "/")
+	if product == "" || product == "Mozilla" {
+		return "Other"
+	}
+	if len(product) > 40 {
+		product = product[:40]
+	}
+	return product
+}
+
+func osFamily(ua string) string {
+	switch {
+	case strings.Contains(ua, "Windows"):
+		return "Windows"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"), strings.Contains(ua, "iPod"):
+		return "iOS"
+	case strings.Contains(ua, "Android"):
+		return "Android"
+	case strings.Contains(ua, "CrOS"):
+		return "ChromeOS"
+	case strings.Contains(ua, "Mac OS X"), strings.Contains(ua, "Macintosh"):
+		return "macOS"
+	case strings.Contains(ua, "Linux"):
+		return "Linux"
+	}
+	return ""
+}
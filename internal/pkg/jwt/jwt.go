@@ -2,7 +2,6 @@ package jwt
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 
@@ -15,22 +14,118 @@ var (
 	ErrExpiredToken = errors.New("token expired")
 )
 
+// Purposes of restricted (challenge) tokens. Access tokens have no purpose.
+const (
+	PurposeMFA            = "mfa"             // Password verified, waiting for the TOTP code
+	PurposeMFAEnroll      = "mfa_enroll"      // Password verified, MFA enrollment is required first
+	PurposePasswordChange = "password_change" // Password verified but expired, it must be changed first
+)
+
 // Claims represents JWT claims
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
-	Role   string    `json:"role"`
+	UserID      uuid.UUID `json:"user_id"`
+	TenantID    uuid.UUID `json:"tid"` // Tenant of the user; Nil in tokens issued before multi-tenancy
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	Roles       []string  `json:"roles,omitempty"` // Every role of the user (Role is the primary one)
+	Permissions []string  `json:"perms,omitempty"` // Permissions granted by Roles when the token was issued
+	SessionID   uuid.UUID `json:"sid"`             // Login session (refresh token family) the token belongs to
+	Purpose     string    `json:"purpose,omitempty"` // Set only on challenge tokens, which are not access tokens
+	Actor       *Actor    `json:"act,omitempty"`     // Set only on impersonation tokens: who is really acting
 	jwt.RegisteredClaims
 }
 
-// GenerateAccessToken generates JWT access token
-func GenerateAccessToken(userID uuid.UUID, email, role string, secret []byte, expiry time.Duration) (string, error) {
+// Grants are the tenant, roles and permissions carried by an access token
+type Grants struct {
+	TenantID    uuid.UUID
+	Role        string // Primary role
+	Roles       []string
+	Permissions []string
+}
+
+// Actor is the "act" (actor) claim of RFC 8693: the party acting on behalf of the subject
+type Actor struct {
+	Subject   string `json:"sub"` // User ID of the actor
+	Email     string `json:"email,omitempty"`
+	SessionID string `json:"sid,omitempty"` // Session of the actor, the token is revoked with it
+}
+
+// GenerateAccessToken generates JWT access token signed with the active key.
+// Every token gets a unique jti and carries the session id used for revocation checks.
+func GenerateAccessToken(userID uuid.UUID, email string, grants Grants, sessionID uuid.UUID, keys *KeySet, expiry time.Duration) (string, error) {
+	jti, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		UserID:    userID,
+		TenantID:    grants.TenantID,
+		Email:       email,
+		Role:        grants.Role,
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+		SessionID:   sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "backend-service",
+			Subject:   userID.String(),
+		},
+	}
+
+	return keys.sign(claims)
+}
+
+// GenerateImpersonationToken generates an access token for userID that carries the
+// real actor in the "act" claim. It belongs to the actor's session, so it is revoked with it.
+func GenerateImpersonationToken(userID uuid.UUID, email string, grants Grants, actor Actor, sessionID uuid.UUID, keys *KeySet, expiry time.Duration) (string, error) {
+	jti, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		UserID:    userID,
+		TenantID:    grants.TenantID,
+		Email:       email,
+		Role:        grants.Role,
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+		SessionID:   sessionID,
+		Actor:     &actor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "backend-service",
+			Subject:   userID.String(),
+		},
+	}
+
+	return keys.sign(claims)
+}
+
+// GenerateChallengeToken generates a short-lived token that only proves one step of
+// the login (e.g. the password) and can only be exchanged for the given purpose
+func GenerateChallengeToken(userID, tenantID uuid.UUID, purpose string, keys *KeySet, expiry time.Duration) (string, error) {
+	jti, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
 	now := time.Now()
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:   userID,
+		TenantID: tenantID,
+		Purpose:  purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti.String(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -39,8 +134,7 @@ func GenerateAccessToken(userID uuid.UUID, email, role string, secret []byte, ex
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secret)
+	return keys.sign(claims)
 }
 
 // GenerateRefreshToken generates a UUID-based refresh token
@@ -52,14 +146,36 @@ func GenerateRefreshToken() (string, error) {
 	return id.String(), nil
 }
 
-// ValidateToken validates and parses JWT token
-func ValidateToken(tokenString string, secret []byte) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+// ValidateToken validates and parses JWT access token.
+// The verification key is selected by the token's kid header.
+// Challenge tokens are rejected.
+func ValidateToken(tokenString string, keys *KeySet) (*Claims, error) {
+	claims, err := parseToken(tokenString, keys)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// ValidateChallengeToken validates a challenge token issued for one of the given purposes
+func ValidateChallengeToken(tokenString string, keys *KeySet, purposes ...string) (*Claims, error) {
+	claims, err := parseToken(tokenString, keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, purpose := range purposes {
+		if claims.Purpose == purpose {
+			return claims, nil
 		}
-		return secret, nil
-	})
+	}
+	return nil, ErrInvalidToken
+}
+
+func parseToken(tokenString string, keys *KeySet) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -0,0 +1,239 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	ErrNoSigningKey = errors.New("no signing key configured")
+	ErrUnknownKey   = errors.New("unknown key id")
+	// ErrKeyPairMismatch is returned when public_key_file does not belong to private_key_file
+	ErrKeyPairMismatch = errors.New("public_key_file does not match private_key_file")
+)
+
+// Key is a single signing/verification key identified by its kid
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{} // nil for verification-only keys
+	verifyKey interface{}
+}
+
+// KeyConfig describes a key loaded from PEM files
+type KeyConfig struct {
+	ID             string
+	Algorithm      string // RS256 or EdDSA
+	PrivateKeyFile string // Required for the active (signing) key
+	PublicKeyFile  string // Optional when PrivateKeyFile is set, must then be its public key
+}
+
+// KeySet holds the key used to sign new tokens and every key accepted for verification.
+// Retired keys stay in the set (public key only) until the tokens they signed expire,
+// so keys can be rotated without logging users out.
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// NewHMACKeySet creates a legacy HS256 key set from a shared secret
+func NewHMACKeySet(secret []byte) *KeySet {
+	key := &Key{
+		Method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+	return &KeySet{
+		active: key,
+		keys:   map[string]*Key{"": key},
+	}
+}
+
+// LoadKeySet loads asymmetric keys from PEM files. activeKeyID selects the signing key.
+func LoadKeySet(configs []KeyConfig, activeKeyID string) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key, len(configs))}
+
+	for _, c := range configs {
+		if c.ID == "" {
+			return nil, errors.New("jwt key: kid is required")
+		}
+		if _, exists := ks.keys[c.ID]; exists {
+			return nil, fmt.Errorf("jwt key %s: duplicate kid", c.ID)
+		}
+		key, err := loadKey(c)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", c.ID, err)
+		}
+		ks.keys[c.ID] = key
+	}
+
+	active, ok := ks.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("jwt active key %q: %w", activeKeyID, ErrUnknownKey)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("jwt active key %s: private key is required", activeKeyID)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+func loadKey(c KeyConfig) (*Key, error) {
+	key := &Key{ID: c.ID}
+
+	var privatePEM, publicPEM []byte
+	var err error
+	if c.PrivateKeyFile != "" {
+		if privatePEM, err = os.ReadFile(c.PrivateKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if c.PublicKeyFile != "" {
+		if publicPEM, err = os.ReadFile(c.PublicKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if privatePEM == nil && publicPEM == nil {
+		return nil, errors.New("private_key_file or public_key_file is required")
+	}
+
+	switch c.Algorithm {
+	case AlgorithmRS256:
+		key.Method = jwt.SigningMethodRS256
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+		}
+		if publicPEM != nil {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return nil, err
+			}
+			if key.verifyKey != nil && !publicKey.Equal(key.verifyKey) {
+				return nil, ErrKeyPairMismatch
+			}
+			key.verifyKey = publicKey
+		}
+	case AlgorithmEdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = privateKey
+			key.verifyKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+		if publicPEM != nil {
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return nil, err
+			}
+			edPublicKey, ok := publicKey.(ed25519.PublicKey)
+			if !ok {
+				return nil, errors.New("public_key_file is not an Ed25519 key")
+			}
+			if key.verifyKey != nil && !edPublicKey.Equal(key.verifyKey) {
+				return nil, ErrKeyPairMismatch
+			}
+			key.verifyKey = edPublicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", c.Algorithm)
+	}
+
+	return key, nil
+}
+
+// sign signs claims with the active key and sets the kid header
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	if ks == nil || ks.active == nil {
+		return "", ErrNoSigningKey
+	}
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+	if ks.active.ID != "" {
+		token.Header["kid"] = ks.active.ID
+	}
+	return token.SignedString(ks.active.signKey)
+}
+
+// keyFunc selects the verification key by the kid header
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	// Never let the token choose the algorithm (alg confusion)
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.verifyKey, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public verification keys. Symmetric (HS256) keys are never published.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	if ks == nil {
+		return set
+	}
+	for _, key := range ks.keys {
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return set
+}
@@ -0,0 +1,307 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidIDToken = errors.New("oidc: invalid id token")
+	ErrNonceMismatch  = errors.New("oidc: nonce mismatch")
+)
+
+// discoveryTTL is how long the provider metadata and JWKS are cached
+const discoveryTTL = time.Hour
+
+// minRefreshInterval limits JWKS refetches triggered by unknown key ids
+const minRefreshInterval = time.Minute
+
+// Config describes an OpenID Connect provider (authorization code flow with PKCE)
+type Config struct {
+	Issuer       string // Discovery is read from Issuer + "/.well-known/openid-configuration"
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string   // Callback registered at the provider
+	Scopes       []string // "openid" is always requested
+}
+
+// Claims are the verified claims of an ID token
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Raw           map[string]interface{} // Every claim, for claim-to-role mapping
+}
+
+// Provider talks to one OpenID Connect provider
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu        sync.Mutex
+	metadata  *metadata
+	keys      map[string]interface{} // kid -> public key
+	fetchedAt time.Time
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewProvider creates a provider. Metadata is discovered on first use.
+// client may be nil to use a default client with a timeout.
+func NewProvider(config Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+	return &Provider{config: config, client: client}
+}
+
+// AuthCodeURL returns the provider URL the user is redirected to
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := []string{"openid"}
+	for _, scope := range p.config.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return md.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the raw ID token
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// client_secret_basic (RFC 6749 section 2.3.1)
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("oidc: token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("oidc: token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc: token endpoint returned %s: %s", resp.Status, body)
+	}
+
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("oidc: token response: %w", err)
+	}
+	if tokenResp.IDToken == "" {
+		return "", errors.New("oidc: token response has no id_token")
+	}
+	return tokenResp.IDToken, nil
+}
+
+// VerifyIDToken checks the signature of an ID token against the provider JWKS,
+// its issuer, audience, expiry and nonce, and returns its claims
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, raw, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.verificationKey(ctx, kid, token.Method)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if tokenNonce, _ := raw["nonce"].(string); tokenNonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	claims := &Claims{Raw: raw}
+	claims.Subject, _ = raw["sub"].(string)
+	claims.Email, _ = raw["email"].(string)
+	claims.Name, _ = raw["name"].(string)
+	switch v := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		// Some providers send it as a string
+		claims.EmailVerified = v == "true"
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// verificationKey returns the provider key with this kid. The JWKS is fetched
+// again when the kid is unknown, so provider key rotation is picked up.
+func (p *Provider) verificationKey(ctx context.Context, kid string, method jwt.SigningMethod) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.keys[kid]
+	stale := time.Since(p.fetchedAt) > discoveryTTL
+	if stale || (!ok && time.Since(p.fetchedAt) > minRefreshInterval) {
+		if err := p.refreshLocked(ctx); err != nil {
+			return nil, err
+		}
+		key, ok = p.keys[kid]
+	}
+	if !ok && kid == "" && len(p.keys) == 1 {
+		// Tokens without kid are accepted when the provider has a single key
+		for _, k := range p.keys {
+			key, ok = k, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("oidc: unknown key id %q", kid)
+	}
+	if !keyMatchesMethod(key, method) {
+		return nil, fmt.Errorf("oidc: key %q cannot verify %s", kid, method.Alg())
+	}
+	return key, nil
+}
+
+// discover returns the cached provider metadata, fetching it when needed
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata == nil || time.Since(p.fetchedAt) > discoveryTTL {
+		if err := p.refreshLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return p.metadata, nil
+}
+
+// refreshLocked fetches the provider metadata and JWKS. p.mu must be held.
+func (p *Provider) refreshLocked(ctx context.Context) error {
+	var md metadata
+	if err := p.getJSON(ctx, p.config.Issuer+"/.well-known/openid-configuration", &md); err != nil {
+		return err
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != p.config.Issuer {
+		return fmt.Errorf("oidc: issuer mismatch: configured %q, provider reports %q", p.config.Issuer, md.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return errors.New("oidc: incomplete provider metadata")
+	}
+
+	var set jwkSet
+	if err := p.getJSON(ctx, md.JWKSURI, &set); err != nil {
+		return err
+	}
+	keys, err := set.publicKeys()
+	if err != nil {
+		return err
+	}
+
+	p.metadata = &md
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	return nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("oidc: GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: GET %s: %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v); err != nil {
+		return fmt.Errorf("oidc: GET %s: %w", url, err)
+	}
+	return nil
+}
+
+// GenerateCodeVerifier returns a PKCE code verifier (RFC 7636), also usable as state or nonce
+func GenerateCodeVerifier() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CodeChallengeS256 returns the S256 code challenge of a verifier
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
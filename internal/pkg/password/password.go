@@ -1,27 +1,145 @@
 package password
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Supported algorithms
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
 const (
 	// DefaultCost is the default bcrypt cost
 	DefaultCost = 12
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
 )
 
-// Hash generates a bcrypt hash from the password
+var (
+	ErrUnsupportedAlgorithm = errors.New("password: unsupported algorithm")
+	ErrInvalidHash          = errors.New("password: invalid hash format")
+)
+
+// Params selects the algorithm and cost of new hashes.
+// Hashes made with other algorithms or parameters still verify, and are
+// reported as needing a rehash.
+type Params struct {
+	Algorithm string // argon2id or bcrypt
+
+	BcryptCost int
+
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// DefaultParams are the OWASP recommended argon2id parameters
+func DefaultParams() Params {
+	return Params{
+		Algorithm:         AlgorithmArgon2id,
+		BcryptCost:        DefaultCost,
+		Argon2Memory:      19 * 1024,
+		Argon2Iterations:  2,
+		Argon2Parallelism: 1,
+	}
+}
+
+var (
+	mu     sync.RWMutex
+	params = DefaultParams()
+)
+
+// Configure sets the parameters of new hashes. Call it once at startup.
+func Configure(p Params) error {
+	switch p.Algorithm {
+	case AlgorithmArgon2id:
+		if p.Argon2Memory < 8*uint32(p.Argon2Parallelism) || p.Argon2Iterations < 1 || p.Argon2Parallelism < 1 {
+			return fmt.Errorf("password: invalid argon2id parameters m=%d t=%d p=%d",
+				p.Argon2Memory, p.Argon2Iterations, p.Argon2Parallelism)
+		}
+	case AlgorithmBcrypt:
+		if p.BcryptCost < bcrypt.MinCost || p.BcryptCost > bcrypt.MaxCost {
+			return fmt.Errorf("password: invalid bcrypt cost %d", p.BcryptCost)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, p.Algorithm)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	params = p
+	return nil
+}
+
+func current() Params {
+	mu.RLock()
+	defer mu.RUnlock()
+	return params
+}
+
+// Hash hashes the password with the configured algorithm.
+// argon2id hashes use the PHC string format:
+// $argon2id$v=19$m=<KiB>,t=<iterations>,p=<parallelism>$<salt>$<hash>
 func Hash(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), DefaultCost)
-	if err != nil {
+	p := current()
+	if p.Algorithm == AlgorithmBcrypt {
+		bytes, err := bcrypt.GenerateFromPassword([]byte(password), p.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
 		return "", err
 	}
-	return string(bytes), nil
+	key := argon2.IDKey([]byte(password), salt, p.Argon2Iterations, p.Argon2Memory, p.Argon2Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Argon2Memory, p.Argon2Iterations, p.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
 }
 
-// Verify compares a password with a hash
-func Verify(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// Verify compares a password with a hash. needsRehash is set when the password
+// matches but the hash was not made with the configured algorithm and parameters,
+// so the caller should store a new Hash of the password.
+func Verify(password, hash string) (ok bool, needsRehash bool) {
+	p := current()
+
+	if strings.HasPrefix(hash, "$argon2id$") {
+		h, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, false
+		}
+		key := argon2.IDKey([]byte(password), h.salt, h.iterations, h.memory, h.parallelism, uint32(len(h.key)))
+		if subtle.ConstantTimeCompare(key, h.key) != 1 {
+			return false, false
+		}
+		return true, p.Algorithm != AlgorithmArgon2id ||
+			h.memory != p.Argon2Memory || h.iterations != p.Argon2Iterations ||
+			h.parallelism != p.Argon2Parallelism || len(h.key) != argon2KeyLength
+	}
+
+	// bcrypt ($2a$, $2b$, $2y$)
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, err != nil || p.Algorithm != AlgorithmBcrypt || cost != p.BcryptCost
 }
 
 // MustHash generates a hash and panics on error (use with caution)
@@ -33,3 +151,40 @@ func MustHash(password string) string {
 	return hash
 }
 
+type argon2idHash struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func decodeArgon2id(hash string) (*argon2idHash, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrInvalidHash
+	}
+
+	h := &argon2idHash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.memory, &h.iterations, &h.parallelism); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if h.iterations < 1 || h.parallelism < 1 {
+		return nil, ErrInvalidHash
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if h.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(h.key) == 0 {
+		return nil, ErrInvalidHash
+	}
+	return h, nil
+}
@@ -0,0 +1,58 @@
+// Package tenant carries the tenant (organisation) of a request in its context.
+//
+// Repositories scope every query to the tenant of the context automatically
+// (see data.registerTenantScope). Requests that name no tenant belong to DefaultID,
+// which also owns the data created before multi-tenancy.
+package tenant
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Header names the tenant of requests without an access token (login, register, system clients)
+const Header = "X-Tenant-ID"
+
+// DefaultID is the tenant of requests that do not name one
+var DefaultID = uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000001"))
+
+type contextKey struct{}
+
+type scope struct {
+	id  uuid.UUID
+	all bool
+}
+
+// NewContext scopes ctx to a tenant
+func NewContext(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, scope{id: id})
+}
+
+// WithAllTenants lifts the tenant scope, for system jobs and for lookups by
+// globally unique secrets (refresh tokens, API keys, one-time tokens) whose
+// tenant is only known once the record is found. Use it sparingly.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, scope{all: true})
+}
+
+// FromContext returns the tenant of ctx (DefaultID when none was set).
+// ok is false when ctx is not scoped to a tenant (WithAllTenants).
+func FromContext(ctx context.Context) (id uuid.UUID, ok bool) {
+	s, found := ctx.Value(contextKey{}).(scope)
+	if !found {
+		return DefaultID, true
+	}
+	if s.all {
+		return uuid.Nil, false
+	}
+	return s.id, true
+}
+
+// OrDefault returns id, or DefaultID for records and tokens without a tenant
+func OrDefault(id *uuid.UUID) uuid.UUID {
+	if id == nil || *id == uuid.Nil {
+		return DefaultID
+	}
+	return *id
+}
@@ -0,0 +1,15 @@
+package tokenhash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash returns the hex encoded HMAC-SHA256 of a bearer token keyed with the server pepper.
+// Only this hash is persisted, so a database leak does not expose usable tokens.
+func Hash(token string, pepper []byte) string {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
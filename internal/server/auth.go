@@ -0,0 +1,35 @@
+package server
+
+import (
+	authv1 "github.com/go-kratos/kratos-layout/api/auth/v1"
+	countryv1 "github.com/go-kratos/kratos-layout/api/country/v1"
+	helloworldv1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	provincev1 "github.com/go-kratos/kratos-layout/api/province/v1"
+	rolev1 "github.com/go-kratos/kratos-layout/api/role/v1"
+	userv1 "github.com/go-kratos/kratos-layout/api/user/v1"
+	wardv1 "github.com/go-kratos/kratos-layout/api/ward/v1"
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+)
+
+// apiServices are served by both the HTTP and gRPC servers. Who may call each rpc
+// is declared with the (authz.rule) option in its proto file, who may see each
+// response field with the (authz.redact) option.
+var apiServices = []string{
+	helloworldv1.Greeter_ServiceDesc.ServiceName,
+	userv1.UserService_ServiceDesc.ServiceName,
+	authv1.AuthService_ServiceDesc.ServiceName,
+	countryv1.CountryService_ServiceDesc.ServiceName,
+	provincev1.ProvinceService_ServiceDesc.ServiceName,
+	wardv1.WardService_ServiceDesc.ServiceName,
+	rolev1.RoleService_ServiceDesc.ServiceName,
+}
+
+// NewAuthzPolicy loads the access policy of every rpc; the app does not start if one is missing.
+func NewAuthzPolicy() (*middleware.AuthzPolicy, error) {
+	return middleware.NewAuthzPolicy(apiServices...)
+}
+
+// NewRedactionPolicy loads the field redaction rules of every response message.
+func NewRedactionPolicy() (*middleware.RedactionPolicy, error) {
+	return middleware.NewRedactionPolicy(apiServices...)
+}
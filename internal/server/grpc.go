@@ -5,21 +5,41 @@ import (
 	countryv1 "github.com/go-kratos/kratos-layout/api/country/v1"
 	helloworldv1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
 	provincev1 "github.com/go-kratos/kratos-layout/api/province/v1"
+	rolev1 "github.com/go-kratos/kratos-layout/api/role/v1"
 	userv1 "github.com/go-kratos/kratos-layout/api/user/v1"
 	wardv1 "github.com/go-kratos/kratos-layout/api/ward/v1"
+	"github.com/go-kratos/kratos-layout/internal/biz"
 	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
 	"github.com/go-kratos/kratos-layout/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, user *service.UserService, auth *service.AuthService, country *service.CountryService, province *service.ProvinceService, ward *service.WardService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, user *service.UserService, auth *service.AuthService, country *service.CountryService, province *service.ProvinceService, ward *service.WardService, role *service.RoleService, keys *jwt.KeySet, authUsecase *biz.AuthUsecase, policy *middleware.AuthzPolicy, redaction *middleware.RedactionPolicy, logger log.Logger) (*grpc.Server, error) {
+	// Auth middleware reads the bearer token from "authorization" metadata
+	authMiddleware := middleware.AuthMiddleware(keys,
+		middleware.WithSessionChecker(authUsecase),
+		middleware.WithAPIKeyAuthenticator(authUsecase),
+		// Users with an expired password may only change it
+		middleware.WithLimitedToken(jwt.PurposePasswordChange, authv1.OperationAuthServiceChangeMyPassword),
+	)
+
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			// Tenant of the "x-tenant-id" metadata, the token's tenant wins
+			middleware.TenantMiddleware(middleware.WithTenantChecker(authUsecase)),
+			// Same policy as the HTTP server
+			selector.Server(authMiddleware, middleware.RequirePermission(policy.Permissions())).
+				Match(policy.RequiresAuth).Build(),
+			// Clear response fields the caller may not see (runs inside auth to know the caller)
+			middleware.RedactResponse(redaction),
 		),
 	}
 	if c.Grpc.Network != "" {
@@ -38,5 +58,17 @@ func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, user *servic
 	countryv1.RegisterCountryServiceServer(srv, country)
 	provincev1.RegisterProvinceServiceServer(srv, province)
 	wardv1.RegisterWardServiceServer(srv, ward)
-	return srv
+	rolev1.RegisterRoleServiceServer(srv, role)
+
+	// Every registered rpc must have a policy
+	var operations []string
+	for name, info := range srv.GetServiceInfo() {
+		for _, method := range info.Methods {
+			operations = append(operations, "/"+name+"/"+method.Name)
+		}
+	}
+	if err := policy.CheckOperations(operations...); err != nil {
+		return nil, err
+	}
+	return srv, nil
 }
@@ -7,10 +7,13 @@ import (
 	countryv1 "github.com/go-kratos/kratos-layout/api/country/v1"
 	helloworldv1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
 	provincev1 "github.com/go-kratos/kratos-layout/api/province/v1"
+	rolev1 "github.com/go-kratos/kratos-layout/api/role/v1"
 	userv1 "github.com/go-kratos/kratos-layout/api/user/v1"
 	wardv1 "github.com/go-kratos/kratos-layout/api/ward/v1"
+	"github.com/go-kratos/kratos-layout/internal/biz"
 	"github.com/go-kratos/kratos-layout/internal/conf"
 	"github.com/go-kratos/kratos-layout/internal/middleware"
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
 	"github.com/go-kratos/kratos-layout/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -20,28 +23,28 @@ import (
 )
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, user *service.UserService, auth *service.AuthService, country *service.CountryService, province *service.ProvinceService, ward *service.WardService, authConfig *conf.Auth, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, user *service.UserService, auth *service.AuthService, country *service.CountryService, province *service.ProvinceService, ward *service.WardService, role *service.RoleService, keys *jwt.KeySet, authUsecase *biz.AuthUsecase, policy *middleware.AuthzPolicy, redaction *middleware.RedactionPolicy, logger log.Logger) *http.Server {
 	// Rate limiting for login endpoint
 	loginRateLimit := middleware.LoginRateLimit()
 
 	// Auth middleware for protected routes
-	authMiddleware := middleware.AuthMiddleware([]byte(authConfig.JwtSecret))
-
-	// Protected routes (require authentication)
-	protectedPaths := []string{
-		"/api/v1/users",
-		"/api/v1/countries", // Country CRUD operations require authentication
-		"/api/v1/provinces", // Province CRUD operations require authentication
-		"/api/v1/wards",    // Ward CRUD operations require authentication
-		"/api/v1/auth/me",
-		"/api/v1/auth/logout",
-		"/api/v1/auth/revoke-all",
-	}
+	authMiddleware := middleware.AuthMiddleware(keys,
+		middleware.WithSessionChecker(authUsecase),
+		middleware.WithAPIKeyAuthenticator(authUsecase),
+		// Users with an expired password may only change it
+		middleware.WithLimitedToken(jwt.PurposePasswordChange, authv1.OperationAuthServiceChangeMyPassword),
+	)
 
 	// Rate limited paths (login endpoint)
 	rateLimitedPaths := []string{
 		"/api/v1/auth/login",
 		"/api/v1/auth/register",
+		"/api/v1/auth/mfa/verify",
+		"/api/v1/auth/password-reset/request",
+		"/api/v1/auth/password-reset/confirm",
+		"/api/v1/auth/password/change",
+		"/api/v1/auth/verify-email",
+		"/api/v1/auth/verify-email/resend",
 	}
 
 	var opts = []http.ServerOption{
@@ -64,20 +67,13 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, user *servic
 					}
 					return false
 				}).Build(),
+			// Tenant of the X-Tenant-ID header (login, register, system clients); the token's tenant wins
+			middleware.TenantMiddleware(middleware.WithTenantChecker(authUsecase)),
 			// Apply auth middleware to protected routes
-			selector.Server(authMiddleware).
-				Match(func(ctx context.Context, operation string) bool {
-					if req, ok := http.RequestFromServerContext(ctx); ok {
-						path := req.URL.Path
-						for _, p := range protectedPaths {
-							// Exact match or prefix match (for /api/v1/users/{id})
-							if path == p || (len(path) > len(p) && path[:len(p)] == p && (path[len(p)] == '/' || path[len(p)] == '?')) {
-								return true
-							}
-						}
-					}
-					return false
-				}).Build(),
+			selector.Server(authMiddleware, middleware.RequirePermission(policy.Permissions())).
+				Match(policy.RequiresAuth).Build(),
+			// Clear response fields the caller may not see (runs inside auth to know the caller)
+			middleware.RedactResponse(redaction),
 		),
 	}
 	if c.Http.Network != "" {
@@ -96,9 +92,13 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, user *servic
 	countryv1.RegisterCountryServiceHTTPServer(srv, country)
 	provincev1.RegisterProvinceServiceHTTPServer(srv, province)
 	wardv1.RegisterWardServiceHTTPServer(srv, ward)
+	rolev1.RegisterRoleServiceHTTPServer(srv, role)
 	
 	// Register Swagger UI
 	RegisterSwaggerUI(srv)
+
+	// Publish JWT verification keys
+	RegisterJWKS(srv, keys)
 	
 	return srv
 }
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// RegisterJWKS registers the public JWKS endpoint so other services can verify our tokens
+func RegisterJWKS(srv *kratoshttp.Server, keys *jwt.KeySet) {
+	srv.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := json.Marshal(keys.JWKS())
+		if err != nil {
+			http.Error(w, "Failed to encode JWKS", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write(data)
+	})
+}
@@ -5,4 +5,4 @@ import (
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer)
+var ProviderSet = wire.NewSet(NewAuthzPolicy, NewRedactionPolicy, NewGRPCServer, NewHTTPServer, NewTokenCleanupServer)
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+var _ transport.Server = (*TokenCleanupServer)(nil)
+
+// TokenCleanupServer runs the auth token cleanup periodically for the lifetime of the app
+type TokenCleanupServer struct {
+	uc   *biz.TokenCleanupUsecase
+	log  *log.Helper
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewTokenCleanupServer new a token cleanup job, registered with the app like the HTTP and gRPC servers.
+func NewTokenCleanupServer(uc *biz.TokenCleanupUsecase, logger log.Logger) *TokenCleanupServer {
+	return &TokenCleanupServer{
+		uc:   uc,
+		log:  log.NewHelper(logger),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Start runs the cleanup now and then every interval until Stop is called
+func (s *TokenCleanupServer) Start(ctx context.Context) error {
+	defer close(s.done)
+
+	cfg := s.uc.Config()
+	if !cfg.Enabled {
+		s.log.Info("[token-cleanup] disabled")
+		return nil
+	}
+	s.log.Infof("[token-cleanup] every %s, retention %s, batch size %d", cfg.Interval, cfg.Retention, cfg.BatchSize)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		// Errors are logged by the usecase; the next run retries
+		_, _, _ = s.uc.PurgeTokens(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Stop cancels a running cleanup and waits for it to return
+func (s *TokenCleanupServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
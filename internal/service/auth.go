@@ -2,13 +2,22 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
 
 	v1 "github.com/go-kratos/kratos-layout/api/auth/v1"
 	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/middleware"
 	"github.com/go-kratos/kratos-layout/internal/pkg/jwt"
+	"github.com/go-kratos/kratos-layout/internal/pkg/validator"
+	"github.com/gofrs/uuid/v5"
 
 	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/proto"
 )
 
 type AuthService struct {
@@ -39,13 +48,7 @@ func (s *AuthService) Login(ctx context.Context, req *v1.LoginRequest) (*v1.Logi
 		return nil, err
 	}
 
-	return &v1.LoginResponse{
-		AccessToken:  result.AccessToken,
-		RefreshToken: result.RefreshToken,
-		ExpiresIn:    result.ExpiresIn,
-		TokenType:    result.TokenType,
-		User:         toProtoAuthUser(result.User),
-	}, nil
+	return toProtoLoginResponse(result), nil
 }
 
 // Register creates new user and returns tokens
@@ -69,11 +72,12 @@ func (s *AuthService) Register(ctx context.Context, req *v1.RegisterRequest) (*v
 	}
 
 	return &v1.RegisterResponse{
-		AccessToken:  result.AccessToken,
-		RefreshToken: result.RefreshToken,
-		ExpiresIn:    result.ExpiresIn,
-		TokenType:    result.TokenType,
-		User:         toProtoAuthUser(result.User),
+		AccessToken:               result.AccessToken,
+		RefreshToken:              result.RefreshToken,
+		ExpiresIn:                 result.ExpiresIn,
+		TokenType:                 result.TokenType,
+		User:                      toProtoAuthUser(result.User),
+		EmailVerificationRequired: result.AccessToken == "",
 	}, nil
 }
 
@@ -114,9 +118,13 @@ func (s *AuthService) GetCurrentUser(ctx context.Context, req *v1.GetCurrentUser
 		return nil, err
 	}
 
-	return &v1.GetCurrentUserResponse{
+	resp := &v1.GetCurrentUserResponse{
 		User: toProtoAuthUser(user),
-	}, nil
+	}
+	if impersonatorID, ok := middleware.GetImpersonatorIDFromContext(ctx); ok {
+		resp.ImpersonatorId = impersonatorID.String()
+	}
+	return resp, nil
 }
 
 // RevokeAllTokens revokes all tokens for current user
@@ -140,16 +148,467 @@ func (s *AuthService) RevokeAllTokens(ctx context.Context, req *v1.RevokeAllToke
 	return &v1.RevokeAllTokensResponse{Success: true}, nil
 }
 
+// EnrollMFA starts TOTP enrollment for the current user
+func (s *AuthService) EnrollMFA(ctx context.Context, req *v1.EnrollMFARequest) (*v1.EnrollMFAResponse, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, errors.Unauthorized("UNAUTHORIZED", "user not authenticated")
+	}
+
+	enrollment, err := s.uc.EnrollMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.EnrollMFAResponse{Enrollment: toProtoMFAEnrollment(enrollment)}, nil
+}
+
+// ConfirmMFAEnrollment enables MFA with the first code
+func (s *AuthService) ConfirmMFAEnrollment(ctx context.Context, req *v1.ConfirmMFAEnrollmentRequest) (*v1.ConfirmMFAEnrollmentResponse, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, errors.Unauthorized("UNAUTHORIZED", "user not authenticated")
+	}
+
+	codes, err := s.uc.ConfirmMFAEnrollment(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ConfirmMFAEnrollmentResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyMFA completes a login with the second factor and returns tokens
+func (s *AuthService) VerifyMFA(ctx context.Context, req *v1.VerifyMFARequest) (*v1.VerifyMFAResponse, error) {
+	if req.MfaToken == "" {
+		return nil, errors.BadRequest("INVALID_ARGUMENT", "mfa_token is required")
+	}
+
+	result, err := s.uc.VerifyMFA(ctx, &biz.VerifyMFARequest{
+		MFAToken:     req.MfaToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		IP:           extractIPFromContext(ctx),
+		UserAgent:    extractUserAgentFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.VerifyMFAResponse{
+		AccessToken:   result.AccessToken,
+		RefreshToken:  result.RefreshToken,
+		ExpiresIn:     result.ExpiresIn,
+		TokenType:     result.TokenType,
+		User:          toProtoAuthUser(result.User),
+		RecoveryCodes: result.RecoveryCodes,
+	}, nil
+}
+
+// DisableMFA turns off MFA for the current user
+func (s *AuthService) DisableMFA(ctx context.Context, req *v1.DisableMFARequest) (*v1.DisableMFAResponse, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, errors.Unauthorized("UNAUTHORIZED", "user not authenticated")
+	}
+
+	if err := s.uc.DisableMFA(ctx, userID, req.Code); err != nil {
+		return nil, err
+	}
+
+	return &v1.DisableMFAResponse{Success: true}, nil
+}
+
+// RequestPasswordReset emails a password reset link
+func (s *AuthService) RequestPasswordReset(ctx context.Context, req *v1.RequestPasswordResetRequest) (*v1.RequestPasswordResetResponse, error) {
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return nil, errors.BadRequest("INVALID_EMAIL", err.Error())
+	}
+
+	if err := s.uc.RequestPasswordReset(ctx, req.Email, extractIPFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &v1.RequestPasswordResetResponse{Success: true}, nil
+}
+
+// ConfirmPasswordReset sets a new password with a reset token
+func (s *AuthService) ConfirmPasswordReset(ctx context.Context, req *v1.ConfirmPasswordResetRequest) (*v1.ConfirmPasswordResetResponse, error) {
+	if req.Token == "" {
+		return nil, biz.ErrResetTokenInvalid
+	}
+
+	// Validate new password
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		return nil, errors.BadRequest("INVALID_PASSWORD", err.Error())
+	}
+
+	if err := s.uc.ConfirmPasswordReset(ctx, req.Token, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	return &v1.ConfirmPasswordResetResponse{Success: true}, nil
+}
+
+// VerifyEmail confirms the email address of the token's user
+func (s *AuthService) VerifyEmail(ctx context.Context, req *v1.VerifyEmailRequest) (*v1.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return nil, biz.ErrVerificationTokenInvalid
+	}
+
+	user, err := s.uc.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.VerifyEmailResponse{User: toProtoAuthUser(user)}, nil
+}
+
+// ResendVerificationEmail sends a new verification email
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, req *v1.ResendVerificationEmailRequest) (*v1.ResendVerificationEmailResponse, error) {
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return nil, errors.BadRequest("INVALID_EMAIL", err.Error())
+	}
+
+	if err := s.uc.ResendVerificationEmail(ctx, req.Email); err != nil {
+		return nil, err
+	}
+
+	return &v1.ResendVerificationEmailResponse{Success: true}, nil
+}
+
+// ChangeMyPassword changes the password of the current user
+func (s *AuthService) ChangeMyPassword(ctx context.Context, req *v1.ChangeMyPasswordRequest) (*v1.ChangeMyPasswordResponse, error) {
+	if req.CurrentPassword == "" {
+		return nil, errors.BadRequest("INVALID_PASSWORD", "current password is required")
+	}
+
+	// Validate new password
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		return nil, errors.BadRequest("INVALID_PASSWORD", err.Error())
+	}
+
+	if err := s.uc.ChangeMyPassword(ctx, &biz.ChangeMyPasswordRequest{
+		CurrentPassword:     req.CurrentPassword,
+		NewPassword:         req.NewPassword,
+		RevokeOtherSessions: req.RevokeOtherSessions,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &v1.ChangeMyPasswordResponse{Success: true}, nil
+}
+
+// StartOIDCLogin returns the identity provider URL that starts a login
+func (s *AuthService) StartOIDCLogin(ctx context.Context, req *v1.StartOIDCLoginRequest) (*v1.StartOIDCLoginResponse, error) {
+	authURL, err := s.uc.StartOIDCLogin(ctx, req.Provider, extractIPFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.StartOIDCLoginResponse{AuthorizationUrl: authURL}, nil
+}
+
+// OIDCCallback completes an identity provider login and returns tokens
+func (s *AuthService) OIDCCallback(ctx context.Context, req *v1.OIDCCallbackRequest) (*v1.LoginResponse, error) {
+	if req.State == "" {
+		return nil, biz.ErrOIDCStateInvalid
+	}
+
+	result, err := s.uc.CompleteOIDCLogin(ctx, &biz.OIDCCallbackRequest{
+		Provider:  req.Provider,
+		Code:      req.Code,
+		State:     req.State,
+		Error:     req.Error,
+		IP:        extractIPFromContext(ctx),
+		UserAgent: extractUserAgentFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoLoginResponse(result), nil
+}
+
+// CreateAPIKey creates an API key for the current user
+func (s *AuthService) CreateAPIKey(ctx context.Context, req *v1.CreateAPIKeyRequest) (*v1.CreateAPIKeyResponse, error) {
+	if req.ExpiresIn < 0 {
+		return nil, errors.BadRequest("INVALID_API_KEY", "expires_in must not be negative")
+	}
+
+	resp, err := s.uc.CreateAPIKey(ctx, &biz.CreateAPIKeyRequest{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresIn: time.Duration(req.ExpiresIn) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateAPIKeyResponse{
+		ApiKey: toProtoAPIKey(resp.APIKey),
+		Key:    resp.Key,
+	}, nil
+}
+
+// ListAPIKeys lists the API keys of the current user
+func (s *AuthService) ListAPIKeys(ctx context.Context, req *v1.ListAPIKeysRequest) (*v1.ListAPIKeysResponse, error) {
+	keys, err := s.uc.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]*v1.APIKey, 0, len(keys))
+	for _, key := range keys {
+		apiKeys = append(apiKeys, toProtoAPIKey(key))
+	}
+	return &v1.ListAPIKeysResponse{ApiKeys: apiKeys}, nil
+}
+
+// RevokeAPIKey revokes an API key of the current user
+func (s *AuthService) RevokeAPIKey(ctx context.Context, req *v1.RevokeAPIKeyRequest) (*v1.RevokeAPIKeyResponse, error) {
+	id, err := uuid.FromString(req.Id)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid API key id")
+	}
+
+	if err := s.uc.RevokeAPIKey(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &v1.RevokeAPIKeyResponse{Success: true}, nil
+}
+
+// ListMySessions lists the active sessions of the current user
+func (s *AuthService) ListMySessions(ctx context.Context, req *v1.ListMySessionsRequest) (*v1.ListSessionsResponse, error) {
+	sessions, err := s.uc.ListMySessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListSessionsResponse{Sessions: toProtoSessions(sessions)}, nil
+}
+
+// RevokeSession revokes one session of the current user
+func (s *AuthService) RevokeSession(ctx context.Context, req *v1.RevokeSessionRequest) (*v1.RevokeSessionResponse, error) {
+	sessionID, err := uuid.FromString(req.Id)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid session id")
+	}
+
+	if err := s.uc.RevokeSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	return &v1.RevokeSessionResponse{Success: true}, nil
+}
+
+// ListMyLoginHistory lists the recent login attempts of the current user
+func (s *AuthService) ListMyLoginHistory(ctx context.Context, req *v1.ListMyLoginHistoryRequest) (*v1.ListLoginHistoryResponse, error) {
+	entries, err := s.uc.ListMyLoginHistory(ctx, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListLoginHistoryResponse{Entries: toProtoLoginHistory(entries)}, nil
+}
+
+// ListUserSessions lists the active sessions of a user (admin only)
+func (s *AuthService) ListUserSessions(ctx context.Context, req *v1.ListUserSessionsRequest) (*v1.ListSessionsResponse, error) {
+	userID, err := uuid.FromString(req.UserId)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
+	}
+
+	sessions, err := s.uc.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListSessionsResponse{Sessions: toProtoSessions(sessions)}, nil
+}
+
+// RevokeUserSession revokes one session of a user (admin only)
+func (s *AuthService) RevokeUserSession(ctx context.Context, req *v1.RevokeUserSessionRequest) (*v1.RevokeSessionResponse, error) {
+	userID, err := uuid.FromString(req.UserId)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
+	}
+	sessionID, err := uuid.FromString(req.Id)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid session id")
+	}
+
+	if err := s.uc.RevokeUserSession(ctx, userID, sessionID); err != nil {
+		return nil, err
+	}
+
+	return &v1.RevokeSessionResponse{Success: true}, nil
+}
+
+// Impersonate issues a short-lived access token acting as another user (admin only)
+func (s *AuthService) Impersonate(ctx context.Context, req *v1.ImpersonateRequest) (*v1.ImpersonateResponse, error) {
+	userID, err := uuid.FromString(req.UserId)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.BadRequest("INVALID_REASON", "reason is required")
+	}
+
+	result, err := s.uc.Impersonate(ctx, &biz.ImpersonateRequest{
+		UserID: userID,
+		Reason: strings.TrimSpace(req.Reason),
+		IP:     extractIPFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ImpersonateResponse{
+		AccessToken: result.AccessToken,
+		ExpiresIn:   result.ExpiresIn,
+		TokenType:   result.TokenType,
+		User:        toProtoAuthUser(result.User),
+	}, nil
+}
+
+// IntrospectToken reports the state of a token to a trusted client (RFC 7662)
+func (s *AuthService) IntrospectToken(ctx context.Context, req *v1.IntrospectTokenRequest) (*v1.IntrospectTokenResponse, error) {
+	clientID, clientSecret, ok := extractBasicAuthFromContext(ctx)
+	if !ok {
+		clientID, clientSecret = req.ClientId, req.ClientSecret
+	}
+	if err := s.uc.AuthenticateIntrospectionClient(clientID, clientSecret); err != nil {
+		return nil, err
+	}
+
+	result, err := s.uc.IntrospectToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Active {
+		return &v1.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	resp := &v1.IntrospectTokenResponse{
+		Active:    true,
+		TokenType: &result.TokenType,
+		Sub:       proto.String(result.UserID.String()),
+		Tid:       proto.String(result.TenantID.String()),
+		Username:  &result.Username,
+		Role:      &result.Role,
+		ClientId:  &clientID,
+	}
+	if result.Scope != "" {
+		resp.Scope = &result.Scope
+	}
+	if result.ExpiresAt != nil {
+		resp.Exp = proto.Uint32(uint32(result.ExpiresAt.Unix()))
+	}
+	if result.IssuedAt != nil {
+		resp.Iat = proto.Uint32(uint32(result.IssuedAt.Unix()))
+	}
+	if result.SessionID != nil {
+		resp.Sid = proto.String(result.SessionID.String())
+	}
+	if result.ActorID != nil {
+		resp.Actor = &v1.IntrospectTokenResponse_Act{Act: &v1.IntrospectionActor{Sub: result.ActorID.String()}}
+	}
+	return resp, nil
+}
+
 // Helper functions
 
+func toProtoLoginResponse(result *biz.LoginResponse) *v1.LoginResponse {
+	return &v1.LoginResponse{
+		AccessToken:   result.AccessToken,
+		RefreshToken:  result.RefreshToken,
+		ExpiresIn:     result.ExpiresIn,
+		TokenType:     result.TokenType,
+		User:          toProtoAuthUser(result.User),
+		MfaRequired:   result.MFARequired,
+		MfaToken:      result.MFAToken,
+		MfaEnrollment: toProtoMFAEnrollment(result.MFAEnrollment),
+
+		PasswordChangeRequired: result.PasswordChangeRequired,
+		PasswordChangeToken:    result.PasswordChangeToken,
+	}
+}
+
+func toProtoAPIKey(key *biz.APIKey) *v1.APIKey {
+	protoKey := &v1.APIKey{
+		Id:        key.ID.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.ScopeList(),
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+	}
+	if key.ExpiresAt != nil {
+		protoKey.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+	if key.LastUsedAt != nil {
+		protoKey.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+	return protoKey
+}
+
+func toProtoSessions(sessions []*biz.Session) []*v1.Session {
+	result := make([]*v1.Session, 0, len(sessions))
+	for _, session := range sessions {
+		protoSession := &v1.Session{
+			Id:        session.ID.String(),
+			IpAddress: session.IPAddress,
+			UserAgent: session.UserAgent,
+			CreatedAt: session.CreatedAt.Format(time.RFC3339),
+			ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+			Current:   session.Current,
+		}
+		if session.LastUsedAt != nil {
+			protoSession.LastUsedAt = session.LastUsedAt.Format(time.RFC3339)
+		}
+		result = append(result, protoSession)
+	}
+	return result
+}
+
+func toProtoLoginHistory(entries []*biz.LoginHistory) []*v1.LoginHistoryEntry {
+	result := make([]*v1.LoginHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, &v1.LoginHistoryEntry{
+			Id:            entry.ID.String(),
+			Success:       entry.Success,
+			FailureReason: entry.FailureReason,
+			Method:        entry.Method,
+			IpAddress:     entry.IPAddress,
+			UserAgent:     entry.UserAgent,
+			Device:        entry.DeviceName,
+			NewDevice:     entry.NewDevice,
+			CreatedAt:     entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+func toProtoMFAEnrollment(enrollment *biz.MFAEnrollment) *v1.MFAEnrollment {
+	if enrollment == nil {
+		return nil
+	}
+	return &v1.MFAEnrollment{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.OTPAuthURI,
+	}
+}
+
 func toProtoAuthUser(user *biz.User) *v1.User {
 	return &v1.User{
-		Id:       user.ID.String(),
-		Email:    user.Email,
-		Username: user.Username,
-		FullName: user.FullName,
-		Role:     user.Role,
-		Status:   user.Status,
+		Id:            user.ID.String(),
+		Email:         user.Email,
+		Username:      user.Username,
+		FullName:      user.FullName,
+		Role:          user.Role,
+		Status:        user.Status,
+		EmailVerified: user.IsEmailVerified(),
 	}
 }
 
@@ -170,11 +629,39 @@ func extractUserAgentFromContext(ctx context.Context) string {
 }
 
 func extractTokenFromContext(ctx context.Context) (string, error) {
-	// Try to get from HTTP request context
-	if req, ok := http.RequestFromServerContext(ctx); ok {
-		authHeader := req.Header.Get("Authorization")
+	// HTTP Authorization header or gRPC "authorization" metadata
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		authHeader := tr.RequestHeader().Get("Authorization")
 		return jwt.ExtractTokenFromHeader(authHeader)
 	}
 	return "", errors.Unauthorized("UNAUTHORIZED", "missing authorization header")
 }
 
+// extractBasicAuthFromContext returns HTTP Basic client credentials (RFC 6749 section 2.3.1:
+// client id and secret are form-encoded before base64)
+func extractBasicAuthFromContext(ctx context.Context) (clientID, clientSecret string, ok bool) {
+	tr, found := transport.FromServerContext(ctx)
+	if !found {
+		return "", "", false
+	}
+	authHeader := tr.RequestHeader().Get("Authorization")
+	if len(authHeader) < 6 || !strings.EqualFold(authHeader[:6], "Basic ") {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(authHeader[6:]))
+	if err != nil {
+		return "", "", false
+	}
+	id, secret, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return "", "", false
+	}
+	if clientID, err = url.QueryUnescape(id); err != nil {
+		return "", "", false
+	}
+	if clientSecret, err = url.QueryUnescape(secret); err != nil {
+		return "", "", false
+	}
+	return clientID, clientSecret, true
+}
+
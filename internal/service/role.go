@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/go-kratos/kratos-layout/api/role/v1"
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+type RoleService struct {
+	v1.UnimplementedRoleServiceServer
+
+	uc *biz.RoleUsecase
+}
+
+func NewRoleService(uc *biz.RoleUsecase) *RoleService {
+	return &RoleService{uc: uc}
+}
+
+// ListPermissions lists every permission known to the server
+func (s *RoleService) ListPermissions(ctx context.Context, req *v1.ListPermissionsRequest) (*v1.ListPermissionsResponse, error) {
+	permissions, err := s.uc.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	protoPermissions := make([]*v1.Permission, len(permissions))
+	for i, p := range permissions {
+		protoPermissions[i] = &v1.Permission{
+			Id:          p.ID.String(),
+			Name:        p.Name,
+			Description: p.Description,
+		}
+	}
+	return &v1.ListPermissionsResponse{Permissions: protoPermissions}, nil
+}
+
+// CreateRole creates a role with the given permissions
+func (s *RoleService) CreateRole(ctx context.Context, req *v1.CreateRoleRequest) (*v1.CreateRoleResponse, error) {
+	role := &biz.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	created, err := s.uc.CreateRole(ctx, role, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CreateRoleResponse{Role: toProtoRole(created)}, nil
+}
+
+// UpdateRole replaces the description and permissions of a role
+func (s *RoleService) UpdateRole(ctx context.Context, req *v1.UpdateRoleRequest) (*v1.UpdateRoleResponse, error) {
+	id, err := uuid.FromString(req.Id)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid role id")
+	}
+
+	updated, err := s.uc.UpdateRole(ctx, id, req.Description, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.UpdateRoleResponse{Role: toProtoRole(updated)}, nil
+}
+
+// DeleteRole deletes a role
+func (s *RoleService) DeleteRole(ctx context.Context, req *v1.DeleteRoleRequest) (*v1.DeleteRoleResponse, error) {
+	id, err := uuid.FromString(req.Id)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid role id")
+	}
+
+	if err := s.uc.DeleteRole(ctx, id); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteRoleResponse{Success: true}, nil
+}
+
+// GetRole gets a role with its permissions
+func (s *RoleService) GetRole(ctx context.Context, req *v1.GetRoleRequest) (*v1.GetRoleResponse, error) {
+	id, err := uuid.FromString(req.Id)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid role id")
+	}
+
+	role, err := s.uc.GetRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetRoleResponse{Role: toProtoRole(role)}, nil
+}
+
+// ListRoles lists every role
+func (s *RoleService) ListRoles(ctx context.Context, req *v1.ListRolesRequest) (*v1.ListRolesResponse, error) {
+	roles, err := s.uc.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ListRolesResponse{Roles: toProtoRoles(roles)}, nil
+}
+
+// ListUserRoles lists the roles of a user
+func (s *RoleService) ListUserRoles(ctx context.Context, req *v1.ListUserRolesRequest) (*v1.ListUserRolesResponse, error) {
+	userID, err := uuid.FromString(req.UserId)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
+	}
+
+	roles, err := s.uc.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ListUserRolesResponse{Roles: toProtoRoles(roles)}, nil
+}
+
+// AssignUserRole gives a role to a user
+func (s *RoleService) AssignUserRole(ctx context.Context, req *v1.AssignUserRoleRequest) (*v1.AssignUserRoleResponse, error) {
+	userID, err := uuid.FromString(req.UserId)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
+	}
+
+	roles, err := s.uc.AssignUserRole(ctx, userID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.AssignUserRoleResponse{Roles: toProtoRoles(roles)}, nil
+}
+
+// RemoveUserRole takes a role from a user
+func (s *RoleService) RemoveUserRole(ctx context.Context, req *v1.RemoveUserRoleRequest) (*v1.RemoveUserRoleResponse, error) {
+	userID, err := uuid.FromString(req.UserId)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
+	}
+
+	roles, err := s.uc.RemoveUserRole(ctx, userID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.RemoveUserRoleResponse{Roles: toProtoRoles(roles)}, nil
+}
+
+// toProtoRole converts biz.Role to proto Role
+func toProtoRole(role *biz.Role) *v1.Role {
+	if role == nil {
+		return nil
+	}
+
+	return &v1.Role{
+		Id:          role.ID.String(),
+		Name:        role.Name,
+		Description: role.Description,
+		System:      role.System,
+		Permissions: role.PermissionNames(),
+		CreatedAt:   role.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   role.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toProtoRoles(roles []*biz.Role) []*v1.Role {
+	protoRoles := make([]*v1.Role, len(roles))
+	for i, role := range roles {
+		protoRoles[i] = toProtoRole(role)
+	}
+	return protoRoles
+}
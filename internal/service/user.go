@@ -6,7 +6,6 @@ import (
 
 	v1 "github.com/go-kratos/kratos-layout/api/user/v1"
 	"github.com/go-kratos/kratos-layout/internal/biz"
-	"github.com/go-kratos/kratos-layout/internal/pkg/password"
 	"github.com/go-kratos/kratos-layout/internal/pkg/validator"
 	"github.com/gofrs/uuid/v5"
 
@@ -40,25 +39,24 @@ func (s *UserService) CreateUser(ctx context.Context, req *v1.CreateUserRequest)
 		return nil, errors.BadRequest("INVALID_PASSWORD", err.Error())
 	}
 
-	// Hash password before saving
-	passwordHash, err := password.Hash(req.Password)
-	if err != nil {
-		return nil, errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
-	}
-
 	user := &biz.User{
-		Email:        req.Email,
-		Username:     req.Username,
-		PasswordHash: passwordHash,
-		FullName:     req.FullName,
-		Gender:       req.Gender,
-		Role:         req.Role,
+		Email:    req.Email,
+		Username: req.Username,
+		FullName: req.FullName,
+		Gender:   req.Gender,
+		Role:     req.Role,
 	}
 
 	if req.Role == "" {
 		user.Role = "user"
 	}
 
+	// Admin vouches for the address
+	if req.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
 	if req.DateOfBirth != "" {
 		dob, err := time.Parse("2006-01-02", req.DateOfBirth)
 		if err == nil {
@@ -66,7 +64,7 @@ func (s *UserService) CreateUser(ctx context.Context, req *v1.CreateUserRequest)
 		}
 	}
 
-	createdUser, err := s.uc.CreateUser(ctx, user)
+	createdUser, err := s.uc.CreateUser(ctx, user, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +89,12 @@ func (s *UserService) UpdateUser(ctx context.Context, req *v1.UpdateUserRequest)
 	// Update fields
 	user.FullName = req.FullName
 	user.Gender = req.Gender
+	if req.Role != "" {
+		user.Role = req.Role
+	}
+	if req.Status != "" {
+		user.Status = req.Status
+	}
 
 	if req.DateOfBirth != "" {
 		dob, err := time.Parse("2006-01-02", req.DateOfBirth)
@@ -123,7 +127,7 @@ func (s *UserService) DeleteUser(ctx context.Context, req *v1.DeleteUserRequest)
 	return &v1.DeleteUserResponse{Success: true}, nil
 }
 
-// ChangePassword changes user password
+// ChangePassword resets the password of a user (admin only)
 func (s *UserService) ChangePassword(ctx context.Context, req *v1.ChangePasswordRequest) (*v1.ChangePasswordResponse, error) {
 	id, err := uuid.FromString(req.Id)
 	if err != nil {
@@ -135,17 +139,25 @@ func (s *UserService) ChangePassword(ctx context.Context, req *v1.ChangePassword
 		return nil, errors.BadRequest("INVALID_PASSWORD", err.Error())
 	}
 
-	// Hash password before updating
-	passwordHash, err := password.Hash(req.NewPassword)
+	if err := s.uc.ChangePassword(ctx, id, req.NewPassword, extractIPFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &v1.ChangePasswordResponse{Success: true}, nil
+}
+
+// UnlockUser clears the failed-login lock of a user
+func (s *UserService) UnlockUser(ctx context.Context, req *v1.UnlockUserRequest) (*v1.UnlockUserResponse, error) {
+	id, err := uuid.FromString(req.Id)
 	if err != nil {
-		return nil, errors.InternalServer("PASSWORD_HASH_ERROR", "failed to hash password")
+		return nil, errors.BadRequest("INVALID_ID", "invalid user id")
 	}
 
-	if err := s.uc.ChangePassword(ctx, id, passwordHash); err != nil {
+	if err := s.uc.UnlockUser(ctx, id); err != nil {
 		return nil, err
 	}
 
-	return &v1.ChangePasswordResponse{Success: true}, nil
+	return &v1.UnlockUserResponse{Success: true}, nil
 }
 
 // GetUser gets a user by ID
@@ -250,6 +262,18 @@ func toProtoUser(user *biz.User) *v1.User {
 		protoUser.LastLoginIp = user.LastLoginIP
 	}
 
+	if user.EmailVerifiedAt != nil {
+		protoUser.EmailVerifiedAt = user.EmailVerifiedAt.Format(time.RFC3339)
+	}
+
+	if user.IsLocked() {
+		protoUser.LockedUntil = user.LockedUntil.Format(time.RFC3339)
+	}
+
+	if user.PasswordChangedAt != nil {
+		protoUser.PasswordChangedAt = user.PasswordChangedAt.Format(time.RFC3339)
+	}
+
 	return protoUser
 }
 
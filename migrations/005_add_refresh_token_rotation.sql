@@ -0,0 +1,17 @@
+-- Migration: Refresh token rotation with reuse detection
+-- Created: 2025-11-25
+
+-- Every token issued from one login shares a family_id; rotated tokens point to their replacement
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS family_id UUID NULL;
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS replaced_by_id UUID NULL;
+
+-- Existing tokens become single-token families
+UPDATE auth_tokens SET family_id = id WHERE family_id IS NULL;
+ALTER TABLE auth_tokens ALTER COLUMN family_id SET NOT NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_family_id ON auth_tokens(family_id);
+
+-- Add comments
+COMMENT ON COLUMN auth_tokens.family_id IS 'Token family: all refresh tokens rotated from the same login';
+COMMENT ON COLUMN auth_tokens.replaced_by_id IS 'Token that replaced this one on rotation (NULL if not rotated)';
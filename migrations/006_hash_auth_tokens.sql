@@ -0,0 +1,55 @@
+-- Migration: Store auth tokens as keyed hashes instead of plaintext
+-- Created: 2025-11-26
+--
+-- Existing rows are re-keyed with HMAC-SHA256 using the server pepper (auth.token_pepper,
+-- or auth.jwt_secret if no pepper is configured). Pass it as a psql variable:
+--   psql ... -v token_pepper='<auth.token_pepper>' -f migrations/006_hash_auth_tokens.sql
+-- (scripts/migrate.sh forwards the TOKEN_PEPPER environment variable).
+-- The pepper is only required while auth_tokens still holds plaintext tokens; the schema
+-- change itself always runs, the new code cannot insert into the old columns.
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- Add hash columns
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS token_hash VARCHAR(64) NULL;
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS refresh_token_hash VARCHAR(64) NULL;
+
+-- Move existing rows over (skipped once the plaintext columns are gone)
+SELECT EXISTS (
+    SELECT 1 FROM information_schema.columns
+    WHERE table_name = 'auth_tokens' AND column_name = 'refresh_token'
+) AS has_plaintext_tokens \gset
+
+\if :has_plaintext_tokens
+SELECT EXISTS (SELECT 1 FROM auth_tokens WHERE refresh_token_hash IS NULL) AS has_unhashed_tokens \gset
+\if :has_unhashed_tokens
+\if :{?token_pepper}
+UPDATE auth_tokens
+SET token_hash = encode(hmac(token, :'token_pepper', 'sha256'), 'hex'),
+    refresh_token_hash = encode(hmac(refresh_token, :'token_pepper', 'sha256'), 'hex')
+WHERE refresh_token_hash IS NULL;
+\else
+-- Stop here: dropping the plaintext columns without hashes would lose every session
+\set ON_ERROR_STOP on
+DO $$ BEGIN
+    RAISE EXCEPTION 'token_pepper is not set, run with -v token_pepper=<auth.token_pepper> (TOKEN_PEPPER for scripts/migrate.sh)';
+END $$;
+\endif
+\endif
+\endif
+
+ALTER TABLE auth_tokens ALTER COLUMN token_hash SET NOT NULL;
+ALTER TABLE auth_tokens ALTER COLUMN refresh_token_hash SET NOT NULL;
+
+-- Move the unique index to the hash column and drop the plaintext secrets
+DROP INDEX IF EXISTS idx_auth_tokens_refresh_token_unique;
+DROP INDEX IF EXISTS idx_auth_tokens_refresh_token;
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS token;
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS refresh_token;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_tokens_refresh_token_hash_unique ON auth_tokens(refresh_token_hash);
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_token_hash ON auth_tokens(token_hash);
+
+-- Add comments
+COMMENT ON COLUMN auth_tokens.token_hash IS 'HMAC-SHA256 (server pepper) of the access token';
+COMMENT ON COLUMN auth_tokens.refresh_token_hash IS 'HMAC-SHA256 (server pepper) of the refresh token';
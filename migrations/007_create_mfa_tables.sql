@@ -0,0 +1,74 @@
+-- Migration: TOTP two-factor authentication
+-- Created: 2025-11-26
+
+-- One TOTP enrollment per user (pending until the first code is confirmed)
+CREATE TABLE IF NOT EXISTS mfa_secrets (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- TOTP information
+    user_id UUID NOT NULL,
+    secret_encrypted TEXT NOT NULL,          -- AES-GCM encrypted base32 secret
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,  -- FALSE while enrollment is pending
+    confirmed_at TIMESTAMP NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- Last accepted TOTP time step (replay protection)
+    
+    -- Foreign key
+    CONSTRAINT fk_mfa_secrets_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- One-time recovery codes
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL, -- HMAC-SHA256 of the code (key: auth.token_pepper)
+    used_at TIMESTAMP NULL,
+    
+    -- Foreign key
+    CONSTRAINT fk_mfa_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mfa_secrets_user_id ON mfa_secrets(user_id);
+CREATE INDEX IF NOT EXISTS idx_mfa_secrets_deleted_at ON mfa_secrets(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id);
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_code_hash ON mfa_recovery_codes(code_hash);
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_deleted_at ON mfa_recovery_codes(deleted_at);
+
+-- Create triggers to automatically update updated_at
+DROP TRIGGER IF EXISTS update_mfa_secrets_updated_at ON mfa_secrets;
+CREATE TRIGGER update_mfa_secrets_updated_at BEFORE UPDATE ON mfa_secrets
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+DROP TRIGGER IF EXISTS update_mfa_recovery_codes_updated_at ON mfa_recovery_codes;
+CREATE TRIGGER update_mfa_recovery_codes_updated_at BEFORE UPDATE ON mfa_recovery_codes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE mfa_secrets IS 'TOTP (RFC 6238) enrollments';
+COMMENT ON TABLE mfa_recovery_codes IS 'One-time MFA recovery codes, stored hashed';
@@ -0,0 +1,44 @@
+-- Migration: Self-service password reset
+-- Created: 2025-11-27
+
+-- Create password_reset_tokens table
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Reset token information
+    user_id UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,  -- HMAC-SHA256 of the token (key: auth.token_pepper)
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,           -- Set when used or superseded by a newer request
+    ip_address VARCHAR(45) NULL,      -- IP that requested the reset
+    
+    -- Foreign key
+    CONSTRAINT fk_password_reset_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_reset_tokens_token_hash ON password_reset_tokens(token_hash);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_expires_at ON password_reset_tokens(expires_at);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_deleted_at ON password_reset_tokens(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_password_reset_tokens_updated_at ON password_reset_tokens;
+CREATE TRIGGER update_password_reset_tokens_updated_at BEFORE UPDATE ON password_reset_tokens
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE password_reset_tokens IS 'Single-use password reset tokens, stored hashed';
@@ -0,0 +1,59 @@
+-- Migration: Email verification
+-- Created: 2025-11-28
+
+-- Only backfill on the first run, later re-runs must not verify new accounts
+SELECT NOT EXISTS (
+    SELECT 1 FROM information_schema.columns
+    WHERE table_name = 'users' AND column_name = 'email_verified_at'
+) AS backfill_email_verified \gset
+
+-- Verification timestamp on users (NULL = not verified)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP NULL;
+
+-- Accounts that existed before verification was introduced are trusted
+\if :backfill_email_verified
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+\endif
+
+COMMENT ON COLUMN users.email_verified_at IS 'When the email address was verified (NULL = not verified)';
+
+-- Create email_verification_tokens table
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Verification token information
+    user_id UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,  -- HMAC-SHA256 of the token (key: auth.token_pepper)
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,           -- Set when used or superseded by a newer email
+    
+    -- Foreign key
+    CONSTRAINT fk_email_verification_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_verification_tokens_token_hash ON email_verification_tokens(token_hash);
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_expires_at ON email_verification_tokens(expires_at);
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_deleted_at ON email_verification_tokens(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_email_verification_tokens_updated_at ON email_verification_tokens;
+CREATE TRIGGER update_email_verification_tokens_updated_at BEFORE UPDATE ON email_verification_tokens
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE email_verification_tokens IS 'Single-use email verification tokens, stored hashed';
@@ -0,0 +1,9 @@
+-- Migration: Per-account lockout after failed logins
+-- Created: 2025-11-29
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_login_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NULL;
+
+-- Add comments
+COMMENT ON COLUMN users.failed_login_attempts IS 'Consecutive failed logins, reset on successful login or admin unlock';
+COMMENT ON COLUMN users.locked_until IS 'Login is rejected until this time (NULL = not locked)';
@@ -0,0 +1,10 @@
+-- Migration: Track when a session was last used
+-- Created: 2025-11-30
+
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP NULL;
+
+-- Existing tokens were last used when they were issued
+UPDATE auth_tokens SET last_used_at = created_at WHERE last_used_at IS NULL;
+
+-- Add comments
+COMMENT ON COLUMN auth_tokens.last_used_at IS 'Last login/refresh with this token';
@@ -0,0 +1,43 @@
+-- Migration: Audit trail for administrative actions
+-- Created: 2025-12-01
+
+-- Create audit_logs table
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Audit information
+    actor_id UUID NULL,                -- User who performed the action (NULL = system)
+    action VARCHAR(100) NOT NULL,      -- e.g. user.password_reset_by_admin
+    target_type VARCHAR(50) NOT NULL,  -- e.g. user
+    target_id UUID NULL,
+    ip_address VARCHAR(45) NULL,
+    details TEXT NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target_id ON audit_logs(target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_deleted_at ON audit_logs(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_audit_logs_updated_at ON audit_logs;
+CREATE TRIGGER update_audit_logs_updated_at BEFORE UPDATE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE audit_logs IS 'Audit trail of security-relevant administrative actions';
@@ -0,0 +1,50 @@
+-- Migration: API keys for machine clients
+-- Created: 2025-12-02
+
+-- Create api_keys table
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- API key information
+    user_id UUID NOT NULL,            -- Owner, the key acts as this user
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,      -- Clear-text start of the key (e.g. bk_3fZq8hT1)
+    key_hash VARCHAR(64) NOT NULL,    -- HMAC-SHA256 of the key (key: auth.token_pepper)
+    scopes TEXT NOT NULL,             -- Space separated operation names / service prefixes
+    expires_at TIMESTAMP NULL,        -- NULL = never expires
+    last_used_at TIMESTAMP NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked_at TIMESTAMP NULL,
+    
+    -- Foreign key
+    CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys(key_hash);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys(prefix);
+CREATE INDEX IF NOT EXISTS idx_api_keys_expires_at ON api_keys(expires_at);
+CREATE INDEX IF NOT EXISTS idx_api_keys_revoked ON api_keys(revoked);
+CREATE INDEX IF NOT EXISTS idx_api_keys_deleted_at ON api_keys(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_api_keys_updated_at ON api_keys;
+CREATE TRIGGER update_api_keys_updated_at BEFORE UPDATE ON api_keys
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE api_keys IS 'Long-lived, scoped API keys of machine clients, stored hashed';
@@ -0,0 +1,81 @@
+-- Migration: External OpenID Connect login
+-- Created: 2025-12-03
+
+-- Create oidc_login_states table
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Login state information
+    provider VARCHAR(100) NOT NULL,
+    state_hash VARCHAR(64) NOT NULL,     -- HMAC-SHA256 of the state (key: auth.token_pepper)
+    nonce VARCHAR(100) NOT NULL,         -- Expected nonce of the ID token
+    code_verifier VARCHAR(100) NOT NULL, -- PKCE verifier sent with the code exchange
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    ip_address VARCHAR(45) NULL          -- IP that started the login
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oidc_login_states_state_hash ON oidc_login_states(state_hash);
+CREATE INDEX IF NOT EXISTS idx_oidc_login_states_expires_at ON oidc_login_states(expires_at);
+CREATE INDEX IF NOT EXISTS idx_oidc_login_states_deleted_at ON oidc_login_states(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_oidc_login_states_updated_at ON oidc_login_states;
+CREATE TRIGGER update_oidc_login_states_updated_at BEFORE UPDATE ON oidc_login_states
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create user_identities table
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Identity information
+    user_id UUID NOT NULL,
+    provider VARCHAR(100) NOT NULL,  -- auth.oidc.providers[].name
+    subject VARCHAR(255) NOT NULL,   -- "sub" claim at the provider
+    email VARCHAR(255) NULL,         -- Email at the provider
+    last_login_at TIMESTAMP NULL,
+    
+    -- Foreign key
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_subject ON user_identities(provider, subject);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_identities_deleted_at ON user_identities(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_user_identities_updated_at ON user_identities;
+CREATE TRIGGER update_user_identities_updated_at BEFORE UPDATE ON user_identities
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE oidc_login_states IS 'Pending OpenID Connect logins (state, nonce, PKCE verifier), single use';
+COMMENT ON TABLE user_identities IS 'Links between users and accounts at external identity providers';
@@ -0,0 +1,46 @@
+-- Migration: Password history and expiry
+-- Created: 2025-12-05
+
+-- When the password was last changed (expiry is measured from it)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP NULL;
+
+-- Existing passwords date from account creation
+UPDATE users SET password_changed_at = created_at WHERE password_changed_at IS NULL;
+
+-- Create password_history table
+CREATE TABLE IF NOT EXISTS password_history (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Previous password
+    user_id UUID NOT NULL,
+    password_hash VARCHAR(255) NOT NULL, -- Hash that was replaced at created_at
+    
+    -- Foreign key
+    CONSTRAINT fk_password_history_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_password_history_user_id_created_at ON password_history(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_password_history_deleted_at ON password_history(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_password_history_updated_at ON password_history;
+CREATE TRIGGER update_password_history_updated_at BEFORE UPDATE ON password_history
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON COLUMN users.password_changed_at IS 'Last password change, passwords expire auth.password_policy.max_age after it';
+COMMENT ON TABLE password_history IS 'Previous password hashes, a new password must not match the last auth.password_policy.history_size';
@@ -0,0 +1,48 @@
+-- Migration: Token cleanup (auth.token_cleanup)
+-- Created: 2025-12-08
+
+-- The cleanup job finds revoked tokens by revocation time
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_revoked_at ON auth_tokens(revoked_at) WHERE revoked = TRUE;
+
+-- Create auth_tokens_archive table (used when auth.token_cleanup.archive is true)
+CREATE TABLE IF NOT EXISTS auth_tokens_archive (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Token information, as it was in auth_tokens
+    user_id UUID NOT NULL,                  -- No foreign key, archived rows outlive their user
+    token_hash VARCHAR(64) NOT NULL,
+    refresh_token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    refresh_expires_at TIMESTAMP NOT NULL,
+    ip_address VARCHAR(45) NULL,
+    user_agent TEXT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    family_id UUID NOT NULL,
+    replaced_by_id UUID NULL,
+    
+    archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_archive_user_id ON auth_tokens_archive(user_id);
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_archive_family_id ON auth_tokens_archive(family_id);
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_archive_archived_at ON auth_tokens_archive(archived_at);
+
+-- Add comments
+COMMENT ON TABLE auth_tokens_archive IS 'Expired and revoked auth tokens moved out of auth_tokens by the cleanup job';
+COMMENT ON COLUMN auth_tokens_archive.archived_at IS 'When the cleanup job moved the row';
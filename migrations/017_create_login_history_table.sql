@@ -0,0 +1,52 @@
+-- Migration: Create login_history table
+-- Created: 2025-12-09
+
+-- Create login_history table
+CREATE TABLE IF NOT EXISTS login_history (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    -- Login attempt
+    user_id UUID NULL,                          -- NULL when no account matched the identifier
+    identifier VARCHAR(255) NOT NULL,           -- Email or username that was entered
+    method VARCHAR(20) NOT NULL,                -- password, mfa, oidc
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50) NULL,
+    ip_address VARCHAR(45) NULL,
+    user_agent TEXT NULL,
+    device_name VARCHAR(100) NULL,              -- Browser and OS family, e.g. "Chrome on Windows"
+    device_fingerprint VARCHAR(32) NULL,        -- Hash of the browser/OS family and IP subnet
+    new_device BOOLEAN NOT NULL DEFAULT FALSE,
+    session_id UUID NULL,                       -- Session started by a successful login
+    
+    -- Foreign key
+    CONSTRAINT fk_login_history_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_login_history_user_id_created_at ON login_history(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_history_known_devices ON login_history(user_id, device_fingerprint) WHERE success = TRUE;
+CREATE INDEX IF NOT EXISTS idx_login_history_ip_address ON login_history(ip_address);
+CREATE INDEX IF NOT EXISTS idx_login_history_deleted_at ON login_history(deleted_at);
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS update_login_history_updated_at ON login_history;
+CREATE TRIGGER update_login_history_updated_at BEFORE UPDATE ON login_history
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE login_history IS 'Every login attempt, successful or failed';
+COMMENT ON COLUMN login_history.device_fingerprint IS 'SHA-256 (truncated) of the user agent family and the /24 (IPv4) or /48 (IPv6) subnet';
+COMMENT ON COLUMN login_history.new_device IS 'First successful login of the user from this device, a notification was sent';
@@ -0,0 +1,132 @@
+-- Migration: Roles and permissions (RBAC)
+-- Created: 2025-12-10
+
+-- Permissions checked by the server, named "<resource>:<action>"
+CREATE TABLE IF NOT EXISTS permissions (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description VARCHAR(255) NULL
+);
+
+-- Named sets of permissions
+CREATE TABLE IF NOT EXISTS roles (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    
+    -- Audit fields
+    created_by UUID NULL,
+    updated_by UUID NULL,
+    
+    -- Optimistic locking
+    version INTEGER NOT NULL DEFAULT 1,
+    
+    -- Status: active, inactive, archived, deleted
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NULL,
+    system BOOLEAN NOT NULL DEFAULT FALSE     -- Built-in role, cannot be deleted
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id UUID NOT NULL,
+    permission_id UUID NOT NULL,
+    
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions(id) ON DELETE CASCADE
+);
+
+-- Roles of a user; users.role (the primary role) is always one of them
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID NOT NULL,
+    role_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_permissions_deleted_at ON permissions(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_roles_deleted_at ON roles(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_role_permissions_permission_id ON role_permissions(permission_id);
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON user_roles(role_id);
+
+-- Create triggers to automatically update updated_at
+DROP TRIGGER IF EXISTS update_permissions_updated_at ON permissions;
+CREATE TRIGGER update_permissions_updated_at BEFORE UPDATE ON permissions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_roles_updated_at ON roles;
+CREATE TRIGGER update_roles_updated_at BEFORE UPDATE ON roles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Seed permissions
+INSERT INTO permissions (id, name, description) VALUES
+    (gen_random_uuid(), 'user:read', 'Get and list users'),
+    (gen_random_uuid(), 'user:write', 'Create, update and delete users'),
+    (gen_random_uuid(), 'user:admin', 'Reset passwords and unlock accounts'),
+    (gen_random_uuid(), 'user:impersonate', 'Act as another user'),
+    (gen_random_uuid(), 'session:admin', 'List and revoke sessions of other users'),
+    (gen_random_uuid(), 'role:admin', 'Manage roles, permissions and role assignments'),
+    (gen_random_uuid(), 'country:read', 'Get and list countries'),
+    (gen_random_uuid(), 'country:write', 'Create, update and delete countries'),
+    (gen_random_uuid(), 'province:read', 'Get and list provinces'),
+    (gen_random_uuid(), 'province:write', 'Create, update and delete provinces'),
+    (gen_random_uuid(), 'ward:read', 'Get and list wards'),
+    (gen_random_uuid(), 'ward:write', 'Create, update and delete wards')
+ON CONFLICT (name) DO NOTHING;
+
+-- Seed roles (unverified is the restricted role of auth.email_verification)
+INSERT INTO roles (id, name, description, system) VALUES
+    (gen_random_uuid(), 'admin', 'Full access', TRUE),
+    (gen_random_uuid(), 'moderator', 'Reads users, manages location data', FALSE),
+    (gen_random_uuid(), 'user', 'Default role of new users', TRUE),
+    (gen_random_uuid(), 'unverified', 'Users waiting for email verification', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name IN (
+    'user:read', 'country:read', 'country:write', 'province:read', 'province:write', 'ward:read', 'ward:write'
+)
+WHERE r.name = 'moderator'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name IN ('country:read', 'province:read', 'ward:read')
+WHERE r.name = 'user'
+ON CONFLICT DO NOTHING;
+
+-- Existing users keep their role
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u JOIN roles r ON r.name = u.role
+ON CONFLICT DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE permissions IS 'Permissions checked per operation, named <resource>:<action>';
+COMMENT ON TABLE roles IS 'Named sets of permissions, a user can hold several roles';
+COMMENT ON TABLE user_roles IS 'Role assignments; users.role is the primary role and always assigned';
+COMMENT ON COLUMN roles.system IS 'Built-in role (admin, user, unverified), cannot be deleted';
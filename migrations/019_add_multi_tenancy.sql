@@ -0,0 +1,127 @@
+-- Migration: Multi-tenancy
+-- Created: 2025-12-11
+
+-- Organisations hosted on the deployment
+CREATE TABLE IF NOT EXISTS tenants (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    name VARCHAR(200) NOT NULL,
+    
+    -- Status: active, inactive
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+);
+
+DROP TRIGGER IF EXISTS update_tenants_updated_at ON tenants;
+CREATE TRIGGER update_tenants_updated_at BEFORE UPDATE ON tenants
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Default tenant (tenant.DefaultID): owns the existing data and requests without a tenant
+INSERT INTO tenants (id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+-- Tenant of every row; NULL only for shared reference data (countries, roles, permissions)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE auth_tokens_archive ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE mfa_secrets ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE mfa_recovery_codes ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE password_reset_tokens ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE oidc_login_states ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE user_identities ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE password_history ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE login_history ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE provinces ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE wards ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE countries ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+ALTER TABLE permissions ADD COLUMN IF NOT EXISTS tenant_id UUID NULL;
+
+-- Existing data belongs to the default tenant; existing countries, roles and permissions become shared
+UPDATE users SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE auth_tokens SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE auth_tokens_archive SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE mfa_secrets SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE mfa_recovery_codes SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE password_reset_tokens SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE email_verification_tokens SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE audit_logs SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE api_keys SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE oidc_login_states SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE user_identities SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE password_history SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE login_history SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE provinces SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE wards SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+
+ALTER TABLE users ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE auth_tokens ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE mfa_secrets ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE mfa_recovery_codes ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE password_reset_tokens ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE email_verification_tokens ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE audit_logs ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE api_keys ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE oidc_login_states ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE user_identities ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE password_history ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE login_history ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE provinces ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE wards ALTER COLUMN tenant_id SET NOT NULL;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_tenant;
+ALTER TABLE users ADD CONSTRAINT fk_users_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+ALTER TABLE countries DROP CONSTRAINT IF EXISTS fk_countries_tenant;
+ALTER TABLE countries ADD CONSTRAINT fk_countries_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+ALTER TABLE provinces DROP CONSTRAINT IF EXISTS fk_provinces_tenant;
+ALTER TABLE provinces ADD CONSTRAINT fk_provinces_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+ALTER TABLE wards DROP CONSTRAINT IF EXISTS fk_wards_tenant;
+ALTER TABLE wards ADD CONSTRAINT fk_wards_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS fk_roles_tenant;
+ALTER TABLE roles ADD CONSTRAINT fk_roles_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+-- Uniqueness becomes per tenant
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_unique;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_username ON users(tenant_id, username);
+
+ALTER TABLE provinces DROP CONSTRAINT IF EXISTS unique_province_code_per_country;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_provinces_tenant_country_code ON provinces(tenant_id, country_id, code);
+
+ALTER TABLE wards DROP CONSTRAINT IF EXISTS unique_ward_code_per_province;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wards_tenant_province_code ON wards(tenant_id, province_id, code);
+
+DROP INDEX IF EXISTS idx_user_identities_provider_subject;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_tenant_provider_subject ON user_identities(tenant_id, provider, subject);
+
+-- Shared rows are unique among themselves, a tenant's rows per tenant
+-- (the API also rejects a tenant code or name that a shared row already uses)
+ALTER TABLE countries DROP CONSTRAINT IF EXISTS countries_code_key;
+ALTER TABLE countries DROP CONSTRAINT IF EXISTS countries_iso3166_alpha3_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_countries_shared_code ON countries(code) WHERE tenant_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_countries_tenant_code ON countries(tenant_id, code) WHERE tenant_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_countries_shared_iso3166_alpha3 ON countries(iso3166_alpha3) WHERE tenant_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_countries_tenant_iso3166_alpha3 ON countries(tenant_id, iso3166_alpha3) WHERE tenant_id IS NOT NULL;
+
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_shared_name ON roles(name) WHERE tenant_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_tenant_name ON roles(tenant_id, name) WHERE tenant_id IS NOT NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_tenant_id ON auth_tokens(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant_id ON audit_logs(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_id ON api_keys(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_login_history_tenant_id ON login_history(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_countries_tenant_id ON countries(tenant_id);
+
+-- Add comments
+COMMENT ON TABLE tenants IS 'Organisations hosted on the deployment, every row of the other tables belongs to one';
+COMMENT ON COLUMN users.tenant_id IS 'Tenant of the user; email and username are unique per tenant';
+COMMENT ON COLUMN countries.tenant_id IS 'Owning tenant, NULL for countries shared by all tenants';
+COMMENT ON COLUMN roles.tenant_id IS 'Owning tenant, NULL for built-in roles shared by all tenants';
+COMMENT ON COLUMN permissions.tenant_id IS 'Always NULL, permissions are defined by the server';
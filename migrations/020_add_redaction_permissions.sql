@@ -0,0 +1,14 @@
+-- Migration: Permissions for redacted response fields
+-- Created: 2025-12-12
+
+-- Fields declared with the (authz.redact) proto option are cleared unless the caller
+-- owns the record or holds one of these permissions
+INSERT INTO permissions (id, name, description) VALUES
+    (gen_random_uuid(), 'user:read_sensitive', 'See private profile fields of other users (date of birth, last login)'),
+    (gen_random_uuid(), 'audit:read', 'See who created and last changed records')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name IN ('user:read_sensitive', 'audit:read')
+WHERE r.name = 'admin' AND r.tenant_id IS NULL
+ON CONFLICT DO NOTHING;
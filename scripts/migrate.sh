@@ -11,6 +11,8 @@ DB_PORT="${DB_PORT:-5432}"
 DB_USER="${DB_USER:-postgres}"
 DB_NAME="${DB_NAME:-bm_staff}"
 MIGRATIONS_DIR="${MIGRATIONS_DIR:-./migrations}"
+# HMAC key for stored token hashes (must match auth.token_pepper, or auth.jwt_secret if unset)
+TOKEN_PEPPER="${TOKEN_PEPPER:-}"
 
 # Colors for output
 GREEN='\033[0;32m'
@@ -29,6 +31,14 @@ if ! command -v psql &> /dev/null; then
     exit 1
 fi
 
+# psql variables available to migrations
+PSQL_VARS=()
+if [ -n "$TOKEN_PEPPER" ]; then
+    PSQL_VARS+=(-v "token_pepper=${TOKEN_PEPPER}")
+else
+    echo -e "${YELLOW}Warning: TOKEN_PEPPER is not set, 006_hash_auth_tokens.sql fails if auth_tokens still holds plaintext tokens${NC}"
+fi
+
 # If specific migration file is provided, run only that
 if [ -n "$1" ]; then
     MIGRATION_FILE="$1"
@@ -37,7 +47,7 @@ if [ -n "$1" ]; then
         exit 1
     fi
     echo -e "${YELLOW}Running migration: $MIGRATION_FILE${NC}"
-    PGPASSWORD="${DB_PASSWORD}" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -f "$MIGRATION_FILE"
+    PGPASSWORD="${DB_PASSWORD}" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" "${PSQL_VARS[@]}" -f "$MIGRATION_FILE"
     echo -e "${GREEN}Migration completed successfully!${NC}"
     exit 0
 fi
@@ -59,7 +69,7 @@ fi
 # Run each migration
 for file in $MIGRATION_FILES; do
     echo -e "${YELLOW}Running migration: $(basename $file)${NC}"
-    PGPASSWORD="${DB_PASSWORD}" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -f "$file"
+    PGPASSWORD="${DB_PASSWORD}" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" "${PSQL_VARS[@]}" -f "$file"
     if [ $? -eq 0 ]; then
         echo -e "${GREEN}✓ $(basename $file) completed${NC}"
     else